@@ -2,24 +2,51 @@ package double
 
 import (
 	"fmt"
+	"github.com/stretchr/testify/assert"
 	"reflect"
+	"strings"
 )
 
-type ArgumentMatcher interface {
-	matches(actual interface{}) bool
+// Matcher matches the actual argument of a method call.
+// It can be used as expected argument in On, When, AssertCalled, NumberOfCallsWithArguments...
+//
+//	type evenMatcher struct{}
+//
+//	func (evenMatcher) Matches(actual interface{}) bool {
+//		aInt, ok := actual.(int)
+//		return ok && aInt%2 == 0
+//	}
+//
+//	func (evenMatcher) Describe() string {
+//		return "even int"
+//	}
+type Matcher interface {
+	// Matches return true if the actual argument is matched
+	Matches(actual interface{}) bool
+	// Describe return a description of the expected argument, used in the messages
+	Describe() string
 }
 
+// ArgumentMatcher is the former name of Matcher.
+//
+// Deprecated: use Matcher instead.
+type ArgumentMatcher = Matcher
+
 const Anything = "double.Anything"
 
-func AnythingOfType(t string) ArgumentMatcher {
+// AnythingOfType matches an argument of the type with the name t.
+// The name can be the short name, the name qualified by the package name or by the package path:
+// "Order", "orders.Order", "*github.com/acme/orders.Order"...
+// A nil argument never matches.
+func AnythingOfType(t string) Matcher {
 	return anythingOfTypeArgument(t)
 }
 
-func IsType(t interface{}) ArgumentMatcher {
+func IsType(t interface{}) Matcher {
 	return &isTypeArgument{t: reflect.TypeOf(t)}
 }
 
-func MatchedBy(fn interface{}) ArgumentMatcher {
+func MatchedBy(fn interface{}) Matcher {
 	fnType := reflect.TypeOf(fn)
 
 	if fnType.Kind() != reflect.Func {
@@ -35,21 +62,159 @@ func MatchedBy(fn interface{}) ArgumentMatcher {
 	return functionMatcherArgument{fn: reflect.ValueOf(fn)}
 }
 
+// Match is the typed alternative to MatchedBy. The function is called only with arguments of type T,
+// or with nil if T is nillable. The description is used in the failure messages.
+// An argument of another type doesn't match, and the failure messages tell the type mismatch.
+//
+//	Stub.On("Save", Match(func(o Order) bool { return o.Total > 100 }, "order over 100"))
+func Match[T any](fn func(T) bool, description string) Matcher {
+	return typedFunctionArgument[T]{fn: fn, description: description}
+}
+
+// Rest matches the trailing arguments of a variadic method, whether they are flattened or passed as a slice.
+// It must be the last expected argument. The matcher can be a value.
+//
+//	Stub.On("Log", "msg", Rest(Anything))
+func Rest(matcher interface{}) Matcher {
+	return restArgument{matcher: matcherOf(matcher)}
+}
+
+// mismatchDescriber is implemented by the matchers that can explain why an argument doesn't match
+type mismatchDescriber interface {
+	describeMismatch(actual interface{}) string
+}
+
+// mismatchOf return the explanation of the matcher, if any, to append to a failure message
+func mismatchOf(matcher Matcher, actual interface{}) string {
+	if describer, ok := matcher.(mismatchDescriber); ok {
+		if mismatch := describer.describeMismatch(actual); mismatch != "" {
+			return ": " + mismatch
+		}
+	}
+	return ""
+}
+
+// matcherOf return the expected argument as a Matcher. A Matcher is returned as is.
+// Anything matches any argument. Any other value matches an equal argument.
+func matcherOf(expected interface{}) Matcher {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher
+	}
+	return equalArgument{expected: expected}
+}
+
+// matchersOf return the expected arguments as matchers (see matcherOf)
+func matchersOf(expected []interface{}) []Matcher {
+	matchers := make([]Matcher, len(expected))
+	for i, value := range expected {
+		matchers[i] = matcherOf(value)
+	}
+	return matchers
+}
+
+// describeAll return the descriptions of the matchers separated by commas
+func describeAll(matchers []Matcher) string {
+	descriptions := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		descriptions[i] = matcher.Describe()
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+type equalArgument struct {
+	expected interface{}
+}
+
+func (e equalArgument) Matches(actual interface{}) bool {
+	return assert.ObjectsAreEqual(e.expected, Anything) || assert.ObjectsAreEqual(actual, Anything) ||
+		assert.ObjectsAreEqual(e.expected, actual)
+}
+
+func (e equalArgument) Describe() string {
+	if assert.ObjectsAreEqual(e.expected, Anything) {
+		return "Anything"
+	}
+	return fmt.Sprintf("%#v", e.expected)
+}
+
+type typedFunctionArgument[T any] struct {
+	fn          func(T) bool
+	description string
+}
+
+func (f typedFunctionArgument[T]) Matches(actual interface{}) bool {
+	value, ok := valueOf[T](actual)
+	return ok && f.fn(value)
+}
+
+func (f typedFunctionArgument[T]) Describe() string {
+	return f.description
+}
+
+func (f typedFunctionArgument[T]) describeMismatch(actual interface{}) string {
+	if _, ok := valueOf[T](actual); ok {
+		return ""
+	}
+	return fmt.Sprintf("expected type %s, actual type %T", reflect.TypeOf((*T)(nil)).Elem(), actual)
+}
+
+type restArgument struct {
+	matcher Matcher
+}
+
+func (r restArgument) Matches(actual interface{}) bool {
+	elements := reflect.ValueOf(actual)
+	if elements.Kind() != reflect.Slice {
+		return false
+	}
+	for i := 0; i < elements.Len(); i++ {
+		if !r.matcher.Matches(elements.Index(i).Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r restArgument) Describe() string {
+	return fmt.Sprintf("Rest(%s)", r.matcher.Describe())
+}
+
 type anythingOfTypeArgument string
 
-func (t anythingOfTypeArgument) matches(actual interface{}) bool {
-	return reflect.TypeOf(actual).Name() == string(t) || reflect.TypeOf(actual).String() == string(t)
+func (t anythingOfTypeArgument) Matches(actual interface{}) bool {
+	actualType := reflect.TypeOf(actual)
+	if actualType == nil {
+		return false
+	}
+	return actualType.Name() == string(t) || actualType.String() == string(t) || qualifiedName(actualType) == string(t)
+}
+
+// qualifiedName return the name of the type qualified by its package path, prefixed by * for the pointers
+func qualifiedName(t reflect.Type) string {
+	prefix := ""
+	for t.Kind() == reflect.Pointer && t.Name() == "" {
+		prefix += "*"
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return prefix + t.String()
+	}
+	return prefix + t.PkgPath() + "." + t.Name()
+}
+
+func (t anythingOfTypeArgument) Describe() string {
+	return fmt.Sprintf("AnythingOfType(%s)", string(t))
 }
 
 type isTypeArgument struct {
 	t reflect.Type
 }
 
-func (t isTypeArgument) String() string {
-	return t.t.Name()
+func (t isTypeArgument) Describe() string {
+	return fmt.Sprintf("IsType(%s)", t.t)
 }
 
-func (t isTypeArgument) matches(actual interface{}) bool {
+func (t isTypeArgument) Matches(actual interface{}) bool {
 	return reflect.TypeOf(actual) == t.t
 }
 
@@ -57,11 +222,11 @@ type functionMatcherArgument struct {
 	fn reflect.Value
 }
 
-func (f functionMatcherArgument) String() string {
-	return fmt.Sprintf("func(%s) bool", f.fn.Type().In(0).String())
+func (f functionMatcherArgument) Describe() string {
+	return fmt.Sprintf("MatchedBy(func(%s) bool)", f.fn.Type().In(0).String())
 }
 
-func (f functionMatcherArgument) matches(argument interface{}) bool {
+func (f functionMatcherArgument) Matches(argument interface{}) bool {
 	expectType := f.fn.Type().In(0)
 
 	argType := reflect.TypeOf(argument)
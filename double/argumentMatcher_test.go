@@ -55,4 +55,92 @@ func TestArgumentMatcher(t *testing.T) {
 
 		})
 	})
+
+	t.Run("Match", func(t *testing.T) {
+		isEven := Match(func(aInt int) bool { return aInt%2 == 0 }, "even int")
+
+		t.Run("Match an argument of the type with the function", func(t *testing.T) {
+			assert.True(t, isEven.Matches(2))
+			assert.False(t, isEven.Matches(1))
+			assert.False(t, isEven.Matches("2"))
+			assert.False(t, isEven.Matches(nil))
+		})
+
+		t.Run("Call the function with nil if the type is nillable", func(t *testing.T) {
+			isNilSlice := Match(func(aSlice []int) bool { return aSlice == nil }, "nil slice")
+
+			assert.True(t, isNilSlice.Matches(nil))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "even int", isEven.Describe())
+		})
+
+		t.Run("Log the type mismatch in the failure messages", func(t *testing.T) {
+			st := &SpiedTestingT{}
+
+			assert.False(t, Arguments{isEven}.Matches(st, "2"))
+			assert.False(t, Arguments{isEven}.Matches(st, 1))
+
+			assert.Equal(t, []string{
+				"\t0: FAIL: (string=2) doesn't match even int: expected type int, actual type string",
+				"\t0: FAIL: (int=1) doesn't match even int",
+			}, st.logMessages)
+		})
+	})
+
+	t.Run("Rest", func(t *testing.T) {
+		t.Run("Match a slice whose elements all match", func(t *testing.T) {
+			assert.True(t, Rest(Gt(0)).Matches([]int{1, 2}))
+			assert.False(t, Rest(Gt(0)).Matches([]int{1, 0}))
+			assert.False(t, Rest(Gt(0)).Matches(1))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "Rest(Anything)", Rest(Anything).Describe())
+		})
+	})
+
+	t.Run("Matcher", func(t *testing.T) {
+		t.Run("Use a custom matcher", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			var args = Arguments{evenMatcher{}}
+
+			assert.True(t, args.Matches(st, 2))
+			assert.Contains(t, st.logMessages, "\t0: PASS: (int=2) matches even int")
+
+			assert.False(t, args.Matches(st, 3))
+			assert.Contains(t, st.logMessages, "\t0: FAIL: (int=3) doesn't match even int")
+		})
+
+		t.Run("Use the description in the failure messages", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			mock := New[MockExample](st)
+			mock.MethodWithOneArgument(3)
+
+			mock.AssertCalled(st, "MethodWithOneArgument", evenMatcher{})
+
+			assert.Contains(t, st.errorMessages[0], "Expected \"MethodWithOneArgument\" to have been called with:\n\t            \t[even int]\n")
+		})
+
+		t.Run("Use the description in the string representation of a call", func(t *testing.T) {
+			call := NewCall("Method", evenMatcher{})
+
+			assert.Equal(t, "Method(double_test.evenMatcher)\n\t\t0: even int", call.String())
+		})
+	})
 }
+
+type evenMatcher struct{}
+
+func (evenMatcher) Matches(actual interface{}) bool {
+	aInt, ok := actual.(int)
+	return ok && aInt%2 == 0
+}
+
+func (evenMatcher) Describe() string {
+	return "even int"
+}
+
+// Check if evenMatcher implements all methods of Matcher
+var _ Matcher = evenMatcher{}
@@ -3,6 +3,7 @@ package double
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"strings"
 )
 
@@ -50,7 +51,11 @@ func (a Arguments) valuesString() string {
 
 	var argVals []string
 	for argIndex, arg := range a {
-		argVals = append(argVals, fmt.Sprintf("%d: %#v", argIndex, arg))
+		if matcher, ok := arg.(Matcher); ok {
+			argVals = append(argVals, fmt.Sprintf("%d: %s", argIndex, matcher.Describe()))
+		} else {
+			argVals = append(argVals, fmt.Sprintf("%d: %#v", argIndex, arg))
+		}
 	}
 	return fmt.Sprintf("\n\t\t%s", strings.Join(argVals, "\n\t\t"))
 
@@ -92,38 +97,229 @@ func (a Arguments) Bool(index int) bool {
 	}
 	return s
 }
+
+// Matches checks the actual arguments against the expected values or matchers.
+// A trailing Rest matches the remaining actual arguments, flattened or not.
 func (a Arguments) Matches(t TestingT, arguments ...interface{}) bool {
 	t.Helper()
 
-	if len(a) != len(arguments) {
+	return a.matches(t, false, arguments)
+}
+
+// matches is similar to Matches, except the last slice of either side is also flattened when the method is variadic
+func (a Arguments) matches(t TestingT, variadic bool, arguments []interface{}) bool {
+	t.Helper()
+
+	expectedArguments, actualArguments, matched := a.variadicShape(variadic, arguments)
+	if matched == nil {
 		t.Logf("Arguments have not the same size: len(expected) == %d ; len(actual) == %d", len(a), len(arguments))
 		return false
 	}
 
 	result := true
-	for i, actual := range arguments {
+	for i, actual := range actualArguments {
 		actualFmt := fmt.Sprintf("(%[1]T=%[1]v)", actual)
-		expected := a[i]
+		expected := expectedArguments[i]
 		expectedFmt := fmt.Sprintf("(%[1]T=%[1]v)", expected)
-		expectedType, ok := expected.(ArgumentMatcher)
-		if ok {
-			objectsMatch := expectedType.matches(actual)
+		objectsMatch := matched[i]
+		if matcher, ok := expected.(Matcher); ok {
 			if objectsMatch {
-				t.Logf("\t%d: PASS: %s matches %s", i, actualFmt, expectedFmt)
+				t.Logf("\t%d: PASS: %s matches %s", i, actualFmt, matcher.Describe())
 			} else {
-				t.Logf("\t%d: FAIL: %s doesn't match %s", i, actualFmt, expectedFmt)
+				t.Logf("\t%d: FAIL: %s doesn't match %s%s", i, actualFmt, matcher.Describe(), mismatchOf(matcher, actual))
 			}
-			result = result && objectsMatch
 		} else {
-			objectAreEqual := assert.ObjectsAreEqual(expected, Anything) || assert.ObjectsAreEqual(actual, Anything) || assert.ObjectsAreEqual(expected, actual)
-			if objectAreEqual {
+			if objectsMatch {
 				t.Logf("\t%d: PASS: %s == %s", i, actualFmt, expectedFmt)
 			} else {
 				t.Logf("\t%d: FAIL: %s != %s", i, actualFmt, expectedFmt)
 			}
-			result = result && objectAreEqual
 		}
+		result = result && objectsMatch
 	}
 
 	return result
 }
+
+// argumentRecorder is implemented by the matchers that record the arguments of the selected or asserted calls, like Captor and Ref
+type argumentRecorder interface {
+	record(actual interface{})
+}
+
+// record the actual arguments in the argument recorders, if all the arguments match.
+// Matching doesn't record anything, so that only the calls selected by the Stub or counted by the Spy are recorded.
+func (a Arguments) record(variadic bool, arguments []interface{}) {
+	expectedArguments, actualArguments, matched := a.variadicShape(variadic, arguments)
+	if matched == nil {
+		return
+	}
+	for _, argumentMatched := range matched {
+		if !argumentMatched {
+			return
+		}
+	}
+	for i, expected := range expectedArguments {
+		if recorder, ok := expected.(argumentRecorder); ok {
+			recorder.record(actualArguments[i])
+		}
+	}
+}
+
+// matchesPredicate checks the whole list of arguments with the predicate (see Stub.OnMatch)
+func (a Arguments) matchesPredicate(t TestingT, predicate func(arguments Arguments) bool) bool {
+	t.Helper()
+
+	if predicate(a) {
+		t.Logf("\tPASS: %s matches the predicate", a.describe())
+		return true
+	}
+	t.Logf("\tFAIL: %s doesn't match the predicate", a.describe())
+	return false
+}
+
+// argumentMatches checks the actual argument against an expected value or Matcher
+func argumentMatches(expected interface{}, actual interface{}) bool {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Matches(actual)
+	}
+	return assert.ObjectsAreEqual(expected, Anything) || assert.ObjectsAreEqual(actual, Anything) || assert.ObjectsAreEqual(expected, actual)
+}
+
+// variadicShape aligns the expected and the actual arguments.
+// A trailing Rest expands to the remaining actual arguments, and the last actual slice can be flattened to match it.
+// When the method is variadic, the last slice of either side can be flattened.
+// The shapes are tried in order: as is, actual flattened, expected flattened. The first shape that matches wins,
+// otherwise the first shape with the same size is kept to log the mismatches.
+// It returns the result of each argument of the shape, or nil if no shape has the same size.
+func (a Arguments) variadicShape(variadic bool, arguments []interface{}) (Arguments, []interface{}, []bool) {
+	type shape struct {
+		expected Arguments
+		actual   []interface{}
+	}
+	shapes := []shape{{a.expandRest(len(arguments)), arguments}}
+	if variadic || a.endsWithRest() {
+		if flattened, ok := flattenLast(arguments); ok {
+			shapes = append(shapes, shape{a.expandRest(len(flattened)), flattened})
+		}
+	}
+	if variadic {
+		if flattened, ok := flattenLast(a); ok {
+			shapes = append(shapes, shape{Arguments(flattened).expandRest(len(arguments)), arguments})
+		}
+	}
+
+	var first *shape
+	var firstMatched []bool
+	for i, candidate := range shapes {
+		if len(candidate.expected) != len(candidate.actual) {
+			continue
+		}
+		matched, ok := candidate.expected.matchEach(candidate.actual)
+		if ok {
+			return candidate.expected, candidate.actual, matched
+		}
+		if first == nil {
+			first, firstMatched = &shapes[i], matched
+		}
+	}
+	if first == nil {
+		return a, arguments, nil
+	}
+	return first.expected, first.actual, firstMatched
+}
+
+func (a Arguments) endsWithRest() bool {
+	if len(a) == 0 {
+		return false
+	}
+	_, ok := a[len(a)-1].(restArgument)
+	return ok
+}
+
+// expandRest replaces a trailing Rest by its matcher as many times as needed to reach size
+func (a Arguments) expandRest(size int) Arguments {
+	if len(a) == 0 {
+		return a
+	}
+	rest, ok := a[len(a)-1].(restArgument)
+	if !ok || size < len(a)-1 {
+		return a
+	}
+	expanded := append(Arguments{}, a[:len(a)-1]...)
+	for len(expanded) < size {
+		expanded = append(expanded, rest.matcher)
+	}
+	return expanded
+}
+
+// matchEach return the result of each argument, and whether they all match
+func (a Arguments) matchEach(arguments []interface{}) ([]bool, bool) {
+	matched := make([]bool, len(arguments))
+	result := true
+	for i, actual := range arguments {
+		matched[i] = argumentMatches(a[i], actual) && a.bindsConsistently(i, arguments)
+		result = result && matched[i]
+	}
+	return matched, result
+}
+
+// bindsConsistently checks that the argument at index is equal to the previous arguments of the same argument binder
+func (a Arguments) bindsConsistently(index int, arguments []interface{}) bool {
+	binder, ok := a[index].(argumentBinder)
+	if !ok {
+		return true
+	}
+	for i := 0; i < index; i++ {
+		if previous, ok := a[i].(argumentBinder); ok && previous == binder && !assert.ObjectsAreEqual(arguments[i], arguments[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenLast expands the last argument when it is a slice
+func flattenLast(arguments []interface{}) ([]interface{}, bool) {
+	if len(arguments) == 0 {
+		return nil, false
+	}
+	if _, ok := arguments[len(arguments)-1].(Matcher); ok {
+		return nil, false
+	}
+	last := reflect.ValueOf(arguments[len(arguments)-1])
+	if last.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	flattened := append([]interface{}{}, arguments[:len(arguments)-1]...)
+	for i := 0; i < last.Len(); i++ {
+		flattened = append(flattened, last.Index(i).Interface())
+	}
+	return flattened, true
+}
+
+// specificity scores how specific the expected arguments are.
+// An exact value is more specific than a Matcher, which is more specific than Anything.
+func (a Arguments) specificity() int {
+	result := 0
+	for _, expected := range a {
+		if _, ok := expected.(Matcher); ok {
+			result++
+		} else if !assert.ObjectsAreEqual(expected, Anything) {
+			result += 2
+		}
+	}
+	return result
+}
+
+// describe return a description of the expected arguments, using the description of the matchers
+func (a Arguments) describe() string {
+	var descriptions []string
+	for _, expected := range a {
+		if matcher, ok := expected.(Matcher); ok {
+			descriptions = append(descriptions, matcher.Describe())
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%v", expected))
+		}
+	}
+	return fmt.Sprintf("[%s]", strings.Join(descriptions, " "))
+}
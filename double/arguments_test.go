@@ -235,23 +235,23 @@ func TestArguments(t *testing.T) {
 			var args = Arguments{AnythingOfType("int"), AnythingOfType("string"), AnythingOfType("*double_test.ExampleType")}
 
 			assert.True(t, args.Matches(st, 1, "String", &ExampleType{true}))
-			assert.Contains(t, st.logMessages, "\t0: PASS: (int=1) matches (double.anythingOfTypeArgument=int)")
-			assert.Contains(t, st.logMessages, "\t1: PASS: (string=String) matches (double.anythingOfTypeArgument=string)")
-			assert.Contains(t, st.logMessages, "\t2: PASS: (*double_test.ExampleType=&{true}) matches (double.anythingOfTypeArgument=*double_test.ExampleType)")
+			assert.Contains(t, st.logMessages, "\t0: PASS: (int=1) matches AnythingOfType(int)")
+			assert.Contains(t, st.logMessages, "\t1: PASS: (string=String) matches AnythingOfType(string)")
+			assert.Contains(t, st.logMessages, "\t2: PASS: (*double_test.ExampleType=&{true}) matches AnythingOfType(*double_test.ExampleType)")
 
 			assert.True(t, args.Matches(st, 2, "any string", &ExampleType{false}))
-			assert.Contains(t, st.logMessages, "\t0: PASS: (int=2) matches (double.anythingOfTypeArgument=int)")
-			assert.Contains(t, st.logMessages, "\t1: PASS: (string=any string) matches (double.anythingOfTypeArgument=string)")
-			assert.Contains(t, st.logMessages, "\t2: PASS: (*double_test.ExampleType=&{false}) matches (double.anythingOfTypeArgument=*double_test.ExampleType)")
+			assert.Contains(t, st.logMessages, "\t0: PASS: (int=2) matches AnythingOfType(int)")
+			assert.Contains(t, st.logMessages, "\t1: PASS: (string=any string) matches AnythingOfType(string)")
+			assert.Contains(t, st.logMessages, "\t2: PASS: (*double_test.ExampleType=&{false}) matches AnythingOfType(*double_test.ExampleType)")
 
 			assert.False(t, args.Matches(st, "any string", "any string", &ExampleType{false}))
-			assert.Contains(t, st.logMessages, "\t0: FAIL: (string=any string) doesn't match (double.anythingOfTypeArgument=int)")
+			assert.Contains(t, st.logMessages, "\t0: FAIL: (string=any string) doesn't match AnythingOfType(int)")
 
 			assert.False(t, args.Matches(st, 2, 2, &ExampleType{false}))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (int=2) doesn't match (double.anythingOfTypeArgument=string)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (int=2) doesn't match AnythingOfType(string)")
 
 			assert.False(t, args.Matches(st, 2, "any string", ExampleType{false}))
-			assert.Contains(t, st.logMessages, "\t2: FAIL: (double_test.ExampleType={false}) doesn't match (double.anythingOfTypeArgument=*double_test.ExampleType)")
+			assert.Contains(t, st.logMessages, "\t2: FAIL: (double_test.ExampleType={false}) doesn't match AnythingOfType(*double_test.ExampleType)")
 		})
 
 		t.Run("compare IsType argument", func(t *testing.T) {
@@ -260,10 +260,10 @@ func TestArguments(t *testing.T) {
 			var args = Arguments([]interface{}{"string", IsType(0), true})
 
 			assert.True(t, args.Matches(st, "string", 123, true))
-			assert.Contains(t, st.logMessages, "\t1: PASS: (int=123) matches (*double.isTypeArgument=int)")
+			assert.Contains(t, st.logMessages, "\t1: PASS: (int=123) matches IsType(int)")
 
 			assert.False(t, args.Matches(st, "string", "string", true))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (string=string) doesn't match (*double.isTypeArgument=int)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (string=string) doesn't match IsType(int)")
 		})
 
 		t.Run("compare Matcher argument", func(t *testing.T) {
@@ -275,16 +275,16 @@ func TestArguments(t *testing.T) {
 			var args = Arguments{"string", MatchedBy(matchFn), true}
 
 			assert.True(t, args.Matches(st, "string", 123, true))
-			assert.Contains(t, st.logMessages, "\t1: PASS: (int=123) matches (double.functionMatcherArgument=func(int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: PASS: (int=123) matches MatchedBy(func(int) bool)")
 
 			assert.False(t, args.Matches(st, "string", 124, true))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (int=124) doesn't match (double.functionMatcherArgument=func(int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (int=124) doesn't match MatchedBy(func(int) bool)")
 
 			assert.False(t, args.Matches(st, "string", false, true))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (bool=false) doesn't match (double.functionMatcherArgument=func(int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (bool=false) doesn't match MatchedBy(func(int) bool)")
 
 			assert.False(t, args.Matches(st, "string", nil, true))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (<nil>=<nil>) doesn't match (double.functionMatcherArgument=func(int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (<nil>=<nil>) doesn't match MatchedBy(func(int) bool)")
 		})
 
 		t.Run("compare Matcher argument with type nillable", func(t *testing.T) {
@@ -296,13 +296,46 @@ func TestArguments(t *testing.T) {
 			var args = Arguments{"string", MatchedBy(matchFn), true}
 
 			assert.True(t, args.Matches(st, "string", nil, true))
-			assert.Contains(t, st.logMessages, "\t1: PASS: (<nil>=<nil>) matches (double.functionMatcherArgument=func([]int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: PASS: (<nil>=<nil>) matches MatchedBy(func([]int) bool)")
 
 			assert.False(t, args.Matches(st, "string", 123, true))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (int=123) doesn't match (double.functionMatcherArgument=func([]int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (int=123) doesn't match MatchedBy(func([]int) bool)")
 
 			assert.False(t, args.Matches(st, "string", false, true))
-			assert.Contains(t, st.logMessages, "\t1: FAIL: (bool=false) doesn't match (double.functionMatcherArgument=func([]int) bool)")
+			assert.Contains(t, st.logMessages, "\t1: FAIL: (bool=false) doesn't match MatchedBy(func([]int) bool)")
+		})
+
+		t.Run("compare variadic arguments with Rest", func(t *testing.T) {
+			st := &SpiedTestingT{}
+
+			var args = Arguments{"msg", Rest(Anything)}
+
+			assert.True(t, args.Matches(st, "msg"))
+			assert.True(t, args.Matches(st, "msg", 1, "two"))
+			assert.True(t, args.Matches(st, "msg", []interface{}{1, "two"}))
+			assert.False(t, args.Matches(st, "other", 1, "two"))
+			assert.False(t, args.Matches(st))
+		})
+
+		t.Run("compare variadic arguments with Rest matcher", func(t *testing.T) {
+			st := &SpiedTestingT{}
+
+			var args = Arguments{"msg", Rest(Gt(0))}
+
+			assert.True(t, args.Matches(st, "msg", 1, 2))
+			assert.True(t, args.Matches(st, "msg", []int{1, 2}))
+			assert.False(t, args.Matches(st, "msg", 1, 0))
+			assert.Contains(t, st.logMessages, "\t2: FAIL: (int=0) doesn't match Gt(0)")
+		})
+
+		t.Run("don't flatten the slice arguments without Rest", func(t *testing.T) {
+			st := &SpiedTestingT{}
+
+			assert.True(t, Arguments{"msg", []int{1, 2}}.Matches(st, "msg", []int{1, 2}))
+			assert.False(t, Arguments{1, 2}.Matches(st, []int{1, 2}))
+			assert.False(t, Arguments{[]int{}}.Matches(st))
+			assert.False(t, Arguments{"a"}.Matches(st, []interface{}{"a"}))
+			assert.False(t, Arguments{[]byte("ab")}.Matches(st, byte('a'), byte('b')))
 		})
 	})
 
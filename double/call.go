@@ -2,6 +2,8 @@ package double
 
 import (
 	"fmt"
+	"math/rand"
+	"reflect"
 	"sync"
 	"time"
 )
@@ -18,6 +20,19 @@ type Call struct {
 	// Holds the arguments that should be returned when this method is called.
 	ReturnArguments Arguments
 
+	// Holds the predicate on the whole list of arguments (see Stub.OnMatch). nil means the Arguments are matched.
+	predicate func(arguments Arguments) bool
+
+	// Indicates that the call is the default answer of the method, whatever its arguments.
+	// A default answer is only used when no other call matches.
+	isDefault bool
+
+	// Holds the state of the scenario in which the call can be called. nil means in any state.
+	requiredState *string
+
+	// Holds the state of the scenario after the call. nil means the state doesn't change.
+	nextState *string
+
 	// The number of times to return the return arguments. 0 means to always return the values.
 	times int
 
@@ -34,14 +49,36 @@ type Call struct {
 	waitFor  <-chan time.Time
 	waitTime time.Duration
 
+	// Holds the model of the time to block until the call returns and its random source.
+	// nil means the call blocks waitTime.
+	latency       Latency
+	latencyRandom *rand.Rand
+
+	// Indicates that the streams returned by the call stay open after the last value
+	keepStreamsOpen bool
+
+	// Holds the streams started by the calls of this method, in call order
+	streams []*Stream
+
 	// Holds a handler used to manipulate arguments content that are passed by
 	// reference. It's useful when mocking methods such as unmarshalers or
-	// decoders.
-	runFn func(Arguments)
+	// decoders. It is either a func(Arguments) or a function with the same
+	// parameters as the stubbed method.
+	runFn interface{}
+
+	// Holds the actions on the arguments (SetArg, CallArg...) executed in declaration order
+	// before the Run handler.
+	argumentActions []argumentAction
+
+	// Holds the functions called by CallArgAsync that have not returned yet
+	asyncCalls sync.WaitGroup
 
 	mutex sync.Mutex
 }
 
+// argumentAction acts on the arguments of a method call
+type argumentAction func(t TestingT, arguments Arguments) error
+
 // NewCall constructor for Call
 func NewCall(methodName string, arguments ...interface{}) *Call {
 	return &Call{MethodName: methodName, Arguments: arguments}
@@ -57,6 +94,60 @@ func (c *Call) Return(arguments ...interface{}) *Call {
 	return c
 }
 
+// ReturnStream specifies a stream as return argument, for methods that return a <-chan T.
+// The values must be a slice of T. Each call gets a fresh channel that emits the values,
+// waiting the interval before each value, and then closes (see KeepStreamOpen).
+// The streams started by the calls are available in Call.Streams, and Spy and Mock also record them in ActualCall.Streams.
+// The test can stop the streams that the consumer doesn't drain (see Call.StopStreams and Stream.Stop).
+//
+//	Stub.On("Subscribe").ReturnStream([]Event{{Name: "created"}, {Name: "deleted"}}, time.Millisecond).Return(nil)
+//
+// Panics if values is not a slice or an array.
+func (c *Call) ReturnStream(values interface{}, interval time.Duration) *Call {
+	return c.ReturnStreamWithDelays(values, func(int) time.Duration { return interval })
+}
+
+// ReturnStreamWithDelays is similar to ReturnStream, except it waits delay(i) before the value at index i.
+//
+//	Stub.On("Subscribe").ReturnStreamWithDelays(events, func(i int) time.Duration { return time.Duration(i) * time.Millisecond }).Return(nil)
+//
+// Panics if values is not a slice or an array.
+func (c *Call) ReturnStreamWithDelays(values interface{}, delay func(i int) time.Duration) *Call {
+	valuesOfStream := reflect.ValueOf(values)
+	if valuesOfStream.Kind() != reflect.Slice && valuesOfStream.Kind() != reflect.Array {
+		panic(fmt.Sprintf("assert: stream: %v is not a slice", values))
+	}
+	return c.Return(&streamReturn{values: valuesOfStream, delay: delay})
+}
+
+// KeepStreamOpen indicates that the streams returned by the call stay open after the last value.
+//
+//	Stub.On("Subscribe").ReturnStream([]Event{{Name: "created"}}, 0).KeepStreamOpen()
+func (c *Call) KeepStreamOpen() *Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.keepStreamsOpen = true
+	return c
+}
+
+// Streams return the streams started by the calls of this method, in call order (see ReturnStream).
+func (c *Call) Streams() []*Stream {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]*Stream(nil), c.streams...)
+}
+
+// StopStreams stops the streams started by the calls of this method, so that their goroutines end
+// even if the consumer doesn't read the channels anymore.
+//
+//	call := Stub.On("Subscribe").ReturnStream(events, time.Millisecond).Return(nil)
+//	defer call.StopStreams()
+func (c *Call) StopStreams() {
+	for _, stream := range c.Streams() {
+		stream.Stop()
+	}
+}
+
 // Once indicates that the mock should only return the value once.
 //
 //	Stub.On("Method", arg1, arg2).Return(returnArg1, returnArg2).Once()
@@ -91,6 +182,28 @@ func (c *Call) Panic(panicMessage string) *Call {
 	return c
 }
 
+// InState restricts the call to the state of the scenario of the stub.
+// The scenario starts in the ScenarioStarted state.
+//
+//	Stub.On("Status").InState("started").Return("running").WillSetState("done")
+//	Stub.On("Status").InState("done").Return("done")
+func (c *Call) InState(state string) *Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.requiredState = &state
+	return c
+}
+
+// WillSetState sets the state of the scenario of the stub when the method is called.
+//
+//	Stub.On("Start").WillSetState("started")
+func (c *Call) WillSetState(state string) *Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.nextState = &state
+	return c
+}
+
 // WaitUntil sets the channel that will block the stub's return until its closed
 // or a message is received.
 //
@@ -109,6 +222,19 @@ func (c *Call) After(duration time.Duration) *Call {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	c.waitTime = duration
+	c.latency = nil
+	return c
+}
+
+// AfterLatency sets a model of how long to block until the call returns.
+// The durations are drawn from a random source initialized with the seed, so a run is reproducible.
+//
+//	Stub.On("Method", arg1, arg2).AfterLatency(NormalLatency(100*time.Millisecond, 20*time.Millisecond), 42)
+func (c *Call) AfterLatency(latency Latency, seed int64) *Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.latency = latency
+	c.latencyRandom = rand.New(rand.NewSource(seed))
 	return c
 }
 
@@ -120,27 +246,140 @@ func (c *Call) After(duration time.Duration) *Call {
 //		arg := args.Get(0).(*map[string]interface{})
 //		arg["foo"] = "bar"
 //	})
-func (c *Call) Run(fn func(Arguments)) *Call {
+//
+// SetArg is a shorter way to only set the content of such arguments.
+//
+// The handler can also take the parameters of the stubbed method. They are checked
+// and passed by reflection when the method is called.
+//
+//	Stub.On("Find", Anything, Anything).Return(nil).Run(func(ctx context.Context, out *User) {
+//		out.Name = "bar"
+//	})
+//
+// A nil handler removes the handler.
+// Panics if fn is not a function or if it returns values.
+func (c *Call) Run(fn interface{}) *Call {
+	if isNilFunc(fn) {
+		fn = nil
+	} else if _, ok := fn.(func(Arguments)); !ok {
+		fnType := reflect.TypeOf(fn)
+		if fnType == nil || fnType.Kind() != reflect.Func {
+			panic(fmt.Sprintf("assert: run: %v is not a func", fn))
+		}
+		if fnType.NumOut() != 0 {
+			panic(fmt.Sprintf("assert: run: %s must not return values", fnType))
+		}
+	}
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	c.runFn = fn
 	return c
 }
 
+// SetArg sets the value of the argument at index when the method is called.
+// The argument must be a pointer, a slice or a map. The value is copied by reflection:
+// a pointer receives the value (or the value pointed by the value), a slice receives the elements
+// of the value as the copy builtin does, and a map receives the entries of the value.
+// Fail the test if the types are incompatible.
+//
+//	Stub.On("Unmarshal", Anything, Anything).Return(nil).SetArg(1, map[string]interface{}{"foo": "bar"})
+func (c *Call) SetArg(index int, value interface{}) *Call {
+	return c.addArgumentAction(func(_ TestingT, arguments Arguments) error {
+		if err := setArgument(arguments, index, value); err != nil {
+			return fmt.Errorf("SetArg(%d): %w", index, err)
+		}
+		return nil
+	})
+}
+
+// CallArg calls the function argument at index with the arguments when the method is called.
+// It can be used when stubbing a method that calls us back (event subscription, visitor...).
+// Fail the test if the argument is not a function or if it can't be called with the arguments.
+//
+//	Stub.On("Subscribe", Anything).Return().CallArg(0, Event{Name: "created"})
+func (c *Call) CallArg(index int, arguments ...interface{}) *Call {
+	return c.addArgumentAction(func(t TestingT, actualArguments Arguments) error {
+		fn, in, err := callbackArguments(actualArguments, index, arguments)
+		if err != nil {
+			return fmt.Errorf("CallArg(%d): %w", index, err)
+		}
+		fn.Call(in)
+		return nil
+	})
+}
+
+// CallArgAsync is similar to CallArg, except the function argument is called in another goroutine
+// after the delay. The method returns without waiting for the call.
+// The goroutine reports a panic of the function to the test, so the test has to wait for it
+// before it ends (see WaitAsync): a failure reported after the end of the test panics.
+//
+//	call := Stub.On("Subscribe", Anything).Return().CallArgAsync(0, 10*time.Millisecond, Event{Name: "created"})
+//	defer call.WaitAsync()
+func (c *Call) CallArgAsync(index int, delay time.Duration, arguments ...interface{}) *Call {
+	return c.addArgumentAction(func(t TestingT, actualArguments Arguments) error {
+		fn, in, err := callbackArguments(actualArguments, index, arguments)
+		if err != nil {
+			return fmt.Errorf("CallArgAsync(%d): %w", index, err)
+		}
+		c.asyncCalls.Add(1)
+		go func() {
+			defer c.asyncCalls.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("CallArgAsync(%d): the function panicked: %v", index, r)
+				}
+			}()
+			time.Sleep(delay)
+			fn.Call(in)
+		}()
+		return nil
+	})
+}
+
+// WaitAsync blocks until the functions called by CallArgAsync have returned.
+func (c *Call) WaitAsync() {
+	c.asyncCalls.Wait()
+}
+
+func (c *Call) addArgumentAction(action argumentAction) *Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.argumentActions = append(c.argumentActions, action)
+	return c
+}
+
 // String return a string representation of a call
 func (c *Call) String() string {
 	return fmt.Sprintf("%s(%s)%s", c.MethodName, c.Arguments.String(), c.Arguments.valuesString())
 }
 
-func (c *Call) matches(t TestingT, methodName string, arguments ...interface{}) bool {
-	return c.MethodName == methodName && c.Arguments.Matches(t, arguments...)
+func (c *Call) matches(t TestingT, methodInformation MethodInformation, arguments ...interface{}) bool {
+	return c.MethodName == methodInformation.Name && (c.isDefault || c.matchesArguments(t, methodInformation.IsVariadic, arguments))
+}
+
+func (c *Call) matchesArguments(t TestingT, variadic bool, arguments Arguments) bool {
+	if c.predicate != nil {
+		return arguments.matchesPredicate(t, c.predicate)
+	}
+	return c.Arguments.matches(t, variadic, arguments)
 }
 
-// canBeCalled return if the method call be called again
-func (c *Call) canBeCalled() bool {
+// specificity scores how specific the call is for the arguments (see Arguments.specificity).
+// A predicate is as specific as a matcher on each argument.
+func (c *Call) specificity(arguments []interface{}) int {
+	if c.predicate != nil {
+		return len(arguments)
+	}
+	return c.Arguments.specificity()
+}
+
+// canBeCalled return if the method call be called again in the state of the scenario
+func (c *Call) canBeCalled(state string) bool {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	return c.times == 0 || c.totalCalls < c.times
+	return (c.requiredState == nil || *c.requiredState == state) &&
+		(c.times == 0 || c.totalCalls < c.times)
 }
 
 // calledPredefinedTimes return if the method was called the predefined times
@@ -151,27 +390,166 @@ func (c *Call) calledPredefinedTimes() bool {
 }
 
 // called executes the predefined behaviour of the call (waitFor, waitTime, panicMessage,,,)
-// and return the predefined return arguments
-func (c *Call) called(arguments ...interface{}) Arguments {
+// and return the predefined return arguments with the started streams.
+// Fail the test if the Run handler can't be called with the arguments.
+func (c *Call) called(t TestingT, arguments ...interface{}) (Arguments, []*Stream) {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
 	c.totalCalls++
+	waitFor, waitTime := c.waitFor, c.waitTime
+	if c.latency != nil {
+		// The random source is not safe for concurrent use, so the latency is drawn with the lock
+		waitTime = c.latency.Duration(c.latencyRandom)
+	}
+	c.mutex.Unlock()
 
-	if c.waitFor != nil {
-		<-c.waitFor
+	// Wait without the lock, so that the concurrent calls wait at the same time
+	if waitFor != nil {
+		<-waitFor
 	} else {
-		time.Sleep(c.waitTime)
+		time.Sleep(waitTime)
 	}
 
+	c.mutex.Lock()
 	if c.panicMessage != nil {
-		panic(*c.panicMessage)
+		panicMessage := *c.panicMessage
+		c.mutex.Unlock()
+		panic(panicMessage)
+	}
+	argumentActions, runFn := c.argumentActions, c.runFn
+	c.mutex.Unlock()
+
+	// The callbacks run without the lock, so that they can call the method again
+	for _, action := range argumentActions {
+		if err := action(t, arguments); err != nil {
+			t.Errorf("%s", err)
+			t.FailNow()
+		}
+	}
+
+	if runFn != nil {
+		if err := callRunFn(runFn, arguments); err != nil {
+			t.Errorf("Run: %s", err)
+			t.FailNow()
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.startStreams()
+}
+
+// startStreams return the predefined return arguments where the streams are replaced by fresh channels
+func (c *Call) startStreams() (Arguments, []*Stream) {
+	returnArguments := c.ReturnArguments
+	var streams []*Stream
+	for i, argument := range c.ReturnArguments {
+		if stream, ok := argument.(*streamReturn); ok {
+			if streams == nil {
+				returnArguments = append(Arguments{}, c.ReturnArguments...)
+			}
+			channel, startedStream := stream.start(c.keepStreamsOpen)
+			returnArguments[i] = channel
+			streams = append(streams, startedStream)
+		}
 	}
+	c.streams = append(c.streams, streams...)
+	return returnArguments, streams
+}
+
+// callRunFn calls the Run handler with the arguments of the method call
+func callRunFn(fn interface{}, arguments Arguments) error {
+	if isNilFunc(fn) {
+		return nil
+	}
+	if argumentsFn, ok := fn.(func(Arguments)); ok {
+		argumentsFn(arguments)
+		return nil
+	}
+
+	fnValue := reflect.ValueOf(fn)
+	in, err := functionArguments(fnValue.Type(), arguments)
+	if err != nil {
+		return err
+	}
+	fnValue.Call(in)
+	return nil
+}
+
+// isNilFunc return if fn is nil or a nil function, like a nil func(Arguments) variable
+func isNilFunc(fn interface{}) bool {
+	fnValue := reflect.ValueOf(fn)
+	return fn == nil || fnValue.Kind() == reflect.Func && fnValue.IsNil()
+}
 
-	if c.runFn != nil {
-		c.runFn(arguments)
+// setArgument copies the value into the pointer, slice or map argument at index
+func setArgument(arguments Arguments, index int, value interface{}) error {
+	if err := checkArgumentIndex(arguments, index); err != nil {
+		return err
 	}
 
-	return c.ReturnArguments
+	target := reflect.ValueOf(arguments[index])
+	source := reflect.ValueOf(value)
+	switch target.Kind() {
+	case reflect.Ptr:
+		if target.IsNil() {
+			return fmt.Errorf("the argument is a nil %s", target.Type())
+		}
+		if source.IsValid() && source.Type() == target.Type() && !source.IsNil() {
+			value = source.Elem().Interface()
+		}
+		sourceValue, err := argumentValue(value, target.Elem().Type())
+		if err != nil {
+			return err
+		}
+		target.Elem().Set(sourceValue)
+	case reflect.Slice:
+		if !source.IsValid() || source.Kind() != reflect.Slice && source.Kind() != reflect.Array ||
+			!source.Type().Elem().AssignableTo(target.Type().Elem()) {
+			return fmt.Errorf("%T is not assignable to %s", value, target.Type())
+		}
+		reflect.Copy(target, source)
+	case reflect.Map:
+		if target.IsNil() {
+			return fmt.Errorf("the argument is a nil %s", target.Type())
+		}
+		if !source.IsValid() || source.Kind() != reflect.Map ||
+			!source.Type().Key().AssignableTo(target.Type().Key()) ||
+			!source.Type().Elem().AssignableTo(target.Type().Elem()) {
+			return fmt.Errorf("%T is not assignable to %s", value, target.Type())
+		}
+		iterator := source.MapRange()
+		for iterator.Next() {
+			target.SetMapIndex(iterator.Key(), iterator.Value())
+		}
+	default:
+		return fmt.Errorf("%T is not a pointer, a slice or a map", arguments[index])
+	}
+	return nil
+}
+
+// callbackArguments returns the function argument at index and the values to call it with
+func callbackArguments(actualArguments Arguments, index int, arguments []interface{}) (reflect.Value, []reflect.Value, error) {
+	if err := checkArgumentIndex(actualArguments, index); err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	fn := reflect.ValueOf(actualArguments[index])
+	if fn.Kind() != reflect.Func {
+		return reflect.Value{}, nil, fmt.Errorf("%T is not a func", actualArguments[index])
+	}
+	if fn.IsNil() {
+		return reflect.Value{}, nil, fmt.Errorf("the argument is a nil %s", fn.Type())
+	}
+
+	in, err := functionArguments(fn.Type(), arguments)
+	return fn, in, err
+}
+
+func checkArgumentIndex(arguments Arguments, index int) error {
+	if index < 0 || index >= len(arguments) {
+		return fmt.Errorf("there are %d argument(s)", len(arguments))
+	}
+	return nil
 }
 
 // Calls collection of Call
@@ -183,14 +561,42 @@ func (c *Calls) append(methodName string, arguments []interface{}) *Call {
 	return call
 }
 
-// find the Call that matches methodName and arguments
-// and check if the method can be called (Once, Twice, Times...)
+// find the Call that matches the method and the arguments
+// and check if the method can be called (Once, Twice, Times, InState...)
+// The default answers are only used when no other Call was found.
 // Return the null object noCallFound if no Call was found
-func (c *Calls) find(t TestingT, methodName string, arguments ...interface{}) *Call {
-	for _, predefinedCall := range *c {
-		if predefinedCall.matches(t, methodName, arguments...) &&
-			predefinedCall.canBeCalled() {
-			return predefinedCall
+func (c *Calls) find(t TestingT, state string, methodInformation MethodInformation, arguments ...interface{}) *Call {
+	for _, isDefault := range []bool{false, true} {
+		for _, predefinedCall := range *c {
+			if predefinedCall.isDefault == isDefault &&
+				predefinedCall.matches(t, methodInformation, arguments...) &&
+				predefinedCall.canBeCalled(state) {
+				return predefinedCall
+			}
+		}
+	}
+	return noCallFound
+}
+
+// findMostSpecific is similar to find, except it returns the matching Call with the highest
+// specificity of arguments (see Arguments.specificity) instead of the first declared one.
+// Ties are broken by the last declared Call.
+func (c *Calls) findMostSpecific(t TestingT, state string, methodInformation MethodInformation, arguments ...interface{}) *Call {
+	for _, isDefault := range []bool{false, true} {
+		var mostSpecificCall *Call
+		highestSpecificity := -1
+		for _, predefinedCall := range *c {
+			specificity := predefinedCall.specificity(arguments)
+			if predefinedCall.isDefault == isDefault &&
+				predefinedCall.matches(t, methodInformation, arguments...) &&
+				predefinedCall.canBeCalled(state) &&
+				specificity >= highestSpecificity {
+				mostSpecificCall = predefinedCall
+				highestSpecificity = specificity
+			}
+		}
+		if mostSpecificCall != nil {
+			return mostSpecificCall
 		}
 	}
 	return noCallFound
@@ -0,0 +1,80 @@
+package double
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Captor is a matcher that matches any argument of type T, or nil if T is nillable.
+// It captures the argument of the call selected by On, or of every call matched by an assertion.
+// Matching alone captures nothing, and each assertion captures again: use a new Captor per assertion.
+// It can be used in On, AssertCalled, NumberOfCallsWithArguments... but not nested in another matcher.
+//
+//	captor := NewCaptor[Order]()
+//	Mock.AssertCalled(t, "Save", captor)
+//	assert.Equal(t, 42, captor.Last().Id)
+type Captor[T any] struct {
+	mutex  sync.Mutex
+	values []T
+}
+
+// NewCaptor creates a Captor of the arguments of type T.
+func NewCaptor[T any]() *Captor[T] {
+	return &Captor[T]{}
+}
+
+func (c *Captor[T]) Matches(actual interface{}) bool {
+	_, ok := valueOf[T](actual)
+	return ok
+}
+
+func (c *Captor[T]) record(actual interface{}) {
+	value, _ := valueOf[T](actual)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values = append(c.values, value)
+}
+
+func (c *Captor[T]) Describe() string {
+	return fmt.Sprintf("Captor[%s]", c.valueType())
+}
+
+// Last returns the last captured value.
+// Panics if no value has been captured.
+func (c *Captor[T]) Last() T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.values) == 0 {
+		panic(fmt.Sprintf("assert: captor: no %s has been captured", c.valueType()))
+	}
+	return c.values[len(c.values)-1]
+}
+
+// All returns the captured values in order.
+func (c *Captor[T]) All() []T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]T{}, c.values...)
+}
+
+// Len returns the number of captured values.
+func (c *Captor[T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.values)
+}
+
+func (c *Captor[T]) valueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// valueOf converts the argument to T. nil is converted to the zero value if T is nillable.
+func valueOf[T any](actual interface{}) (T, bool) {
+	var zero T
+	if actual == nil {
+		return zero, isNilSupported(reflect.TypeOf((*T)(nil)).Elem())
+	}
+	value, ok := actual.(T)
+	return value, ok
+}
@@ -0,0 +1,168 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestCaptor(t *testing.T) {
+	t.Run("Match the arguments of the type", func(t *testing.T) {
+		captor := NewCaptor[int]()
+
+		assert.True(t, captor.Matches(1))
+		assert.False(t, captor.Matches("1"))
+		assert.False(t, captor.Matches(nil))
+		assert.True(t, NewCaptor[*ExampleType]().Matches(nil))
+	})
+
+	t.Run("Capture the arguments when all the arguments match", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := New[SpyExample](tt)
+		captor := NewCaptor[int]()
+
+		spy.MethodWithArguments(1, "a", 1.0)
+		spy.MethodWithArguments(2, "b", 1.0)
+		spy.MethodWithArguments(3, "a", 1.0)
+		spy.NumberOfCallsWithArguments("MethodWithArguments", captor, "a", Anything)
+
+		assert.Equal(t, 2, captor.Len())
+		assert.Equal(t, []int{1, 3}, captor.All())
+		assert.Equal(t, 3, captor.Last())
+	})
+
+	t.Run("Don't capture while matching", func(t *testing.T) {
+		captor := NewCaptor[int]()
+
+		assert.True(t, Arguments{captor}.Matches(&SpiedTestingT{}, 1))
+		assert.Equal(t, 0, captor.Len())
+	})
+
+	t.Run("Capture nil if the type is nillable", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := New[SpyExample](tt)
+		captor := NewCaptor[*ExampleType]()
+
+		spy.MethodWithReferenceArgument(nil)
+		spy.NumberOfCallsWithArguments("MethodWithReferenceArgument", captor)
+
+		assert.Equal(t, 1, captor.Len())
+		assert.Nil(t, captor.Last())
+	})
+
+	t.Run("Panic if nothing is captured", func(t *testing.T) {
+		captor := NewCaptor[int]()
+
+		assert.PanicsWithValue(t, "assert: captor: no int has been captured", func() {
+			captor.Last()
+		})
+		assert.Empty(t, captor.All())
+	})
+
+	t.Run("Describe", func(t *testing.T) {
+		assert.Equal(t, "Captor[string]", NewCaptor[string]().Describe())
+	})
+
+	t.Run("Capture in On", func(t *testing.T) {
+		tt := new(testing.T)
+		stub := New[StubExample](tt)
+		captor := NewCaptor[string]()
+		stub.On("MethodWithArgumentsAndReturnArguments", 1, captor, Anything).Return(1, nil)
+
+		stub.MethodWithArgumentsAndReturnArguments(1, "first", 1.0)
+		stub.MethodWithArgumentsAndReturnArguments(1, "second", 2.0)
+
+		assert.Equal(t, []string{"first", "second"}, captor.All())
+	})
+
+	t.Run("Capture in On the arguments as they were at call time", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := New[SpyExample](tt)
+		spy.SnapshotArguments()
+		captor := NewCaptor[*ExampleType]()
+		spy.On("MethodWithReferenceArgument", captor).Return()
+		argument := &ExampleType{}
+
+		spy.MethodWithReferenceArgument(argument)
+		argument.ran = true
+
+		assert.False(t, captor.Last().ran)
+	})
+
+	t.Run("Capture only in the selected call of On", func(t *testing.T) {
+		tt := new(testing.T)
+		stub := New[StubExample](tt)
+		captor := NewCaptor[int]()
+		stub.On("MethodWithArgumentsAndReturnArguments", captor, Anything, Anything).Return(1, nil).Once()
+		stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(2, nil)
+
+		stub.MethodWithArgumentsAndReturnArguments(10, "a", 1.0)
+		stub.MethodWithArgumentsAndReturnArguments(20, "a", 1.0)
+		stub.MethodWithArgumentsAndReturnArguments(30, "a", 1.0)
+
+		assert.Equal(t, []int{10}, captor.All())
+	})
+
+	t.Run("Don't capture again in AssertExpectations", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		captor := NewCaptor[int]()
+		mock.On("MethodWithArguments", captor, Anything, Anything).Return()
+
+		mock.MethodWithArguments(1, "a", 1.0)
+
+		assert.True(t, mock.AssertExpectations(tt))
+		assert.Equal(t, []int{1}, captor.All())
+	})
+
+	t.Run("Capture in AssertCalled", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		captor := NewCaptor[float64]()
+
+		mock.MethodWithArguments(1, "a", 1.5)
+		mock.Method()
+		mock.MethodWithArguments(2, "b", 2.5)
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithArguments", 2, "b", captor))
+		assert.Equal(t, 2.5, captor.Last())
+	})
+
+	t.Run("Capture again in each assertion", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		captor := NewCaptor[float64]()
+
+		mock.MethodWithArguments(1, "a", 1.5)
+		mock.AssertCalled(tt, "MethodWithArguments", 1, "a", captor)
+		mock.AssertCalled(tt, "MethodWithArguments", 1, "a", captor)
+
+		assert.Equal(t, []float64{1.5, 1.5}, captor.All())
+	})
+
+	t.Run("Don't capture in AssertNotCalled", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		captor := NewCaptor[float64]()
+		ref := NewRef[int]()
+
+		mock.MethodWithArguments(1, "a", 1.5)
+
+		assert.False(t, mock.AssertNotCalled(tt, "MethodWithArguments", ref, "a", captor))
+		assert.Equal(t, 0, captor.Len())
+		assert.False(t, ref.IsBound())
+	})
+
+	t.Run("Capture in NumberOfCallsWithArguments", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := New[SpyExample](tt)
+		captor := NewCaptor[int]()
+
+		spy.MethodWithArguments(1, "a", 1.5)
+		spy.MethodWithArguments(2, "b", 2.5)
+
+		assert.Equal(t, 2, spy.NumberOfCallsWithArguments("MethodWithArguments", captor, Anything, Anything))
+		assert.Equal(t, []int{1, 2}, captor.All())
+	})
+}
@@ -0,0 +1,210 @@
+package double
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Len matches a slice, an array, a map, a string or a channel argument of length n.
+//
+//	Stub.On("SaveAll", Len(3))
+func Len(n int) Matcher {
+	return lenArgument(n)
+}
+
+// ContainsElement matches a slice or an array argument that contains the element.
+// The element can be a matcher.
+//
+//	Stub.On("SaveAll", ContainsElement(item42))
+func ContainsElement(element interface{}) Matcher {
+	return containsElementArgument{matcher: matcherOf(element)}
+}
+
+// ElementsMatch matches a slice or an array argument with the same elements in any order.
+// The elements can be matchers.
+//
+//	Stub.On("SaveAll", ElementsMatch(item2, item1))
+func ElementsMatch(elements ...interface{}) Matcher {
+	return elementsArgument{name: "ElementsMatch", matchers: matchersOf(elements), sameLength: true}
+}
+
+// SubsetOf matches a slice or an array argument whose elements are all among the elements in any order.
+// Each element can match only one element of the argument. The elements can be matchers.
+//
+//	Stub.On("Delete", SubsetOf(1, 2, 3))
+func SubsetOf(elements ...interface{}) Matcher {
+	return elementsArgument{name: "SubsetOf", matchers: matchersOf(elements)}
+}
+
+// HasKey matches a map argument that contains the key.
+// The key can be a matcher.
+//
+//	Stub.On("Send", HasKey("Authorization"))
+func HasKey(key interface{}) Matcher {
+	return hasEntryArgument{key: matcherOf(key)}
+}
+
+// HasEntry matches a map argument that contains the key with the value.
+// The key and the value can be matchers.
+//
+//	Stub.On("Send", HasEntry("Content-Type", HasPrefix("application/json")))
+func HasEntry(key interface{}, value interface{}) Matcher {
+	return hasEntryArgument{key: matcherOf(key), value: matcherOf(value)}
+}
+
+// Each matches a slice or an array argument whose elements all match.
+// The matcher can be a value.
+//
+//	Stub.On("Delete", Each(Gt(0)))
+func Each(matcher interface{}) Matcher {
+	return eachArgument{matcher: matcherOf(matcher)}
+}
+
+type lenArgument int
+
+func (l lenArgument) Matches(actual interface{}) bool {
+	value := reflect.ValueOf(actual)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return value.Len() == int(l)
+	default:
+		return false
+	}
+}
+
+func (l lenArgument) Describe() string {
+	return fmt.Sprintf("Len(%d)", int(l))
+}
+
+type containsElementArgument struct {
+	matcher Matcher
+}
+
+func (c containsElementArgument) Matches(actual interface{}) bool {
+	elements, ok := elementsOf(actual)
+	if !ok {
+		return false
+	}
+	for _, element := range elements {
+		if c.matcher.Matches(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c containsElementArgument) Describe() string {
+	return fmt.Sprintf("ContainsElement(%s)", c.matcher.Describe())
+}
+
+type elementsArgument struct {
+	name       string
+	matchers   []Matcher
+	sameLength bool
+}
+
+func (e elementsArgument) Matches(actual interface{}) bool {
+	elements, ok := elementsOf(actual)
+	if !ok || e.sameLength && len(elements) != len(e.matchers) {
+		return false
+	}
+	return matchEachElement(elements, e.matchers)
+}
+
+func (e elementsArgument) Describe() string {
+	return fmt.Sprintf("%s(%s)", e.name, describeAll(e.matchers))
+}
+
+type hasEntryArgument struct {
+	key   Matcher
+	value Matcher
+}
+
+func (h hasEntryArgument) Matches(actual interface{}) bool {
+	value := reflect.ValueOf(actual)
+	if value.Kind() != reflect.Map {
+		return false
+	}
+
+	iterator := value.MapRange()
+	for iterator.Next() {
+		if h.key.Matches(iterator.Key().Interface()) &&
+			(h.value == nil || h.value.Matches(iterator.Value().Interface())) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h hasEntryArgument) Describe() string {
+	if h.value == nil {
+		return fmt.Sprintf("HasKey(%s)", h.key.Describe())
+	}
+	return fmt.Sprintf("HasEntry(%s, %s)", h.key.Describe(), h.value.Describe())
+}
+
+type eachArgument struct {
+	matcher Matcher
+}
+
+func (e eachArgument) Matches(actual interface{}) bool {
+	elements, ok := elementsOf(actual)
+	if !ok {
+		return false
+	}
+	for _, element := range elements {
+		if !e.matcher.Matches(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e eachArgument) Describe() string {
+	return fmt.Sprintf("Each(%s)", e.matcher.Describe())
+}
+
+// elementsOf return the elements of a slice or an array argument
+func elementsOf(actual interface{}) ([]interface{}, bool) {
+	value := reflect.ValueOf(actual)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	elements := make([]interface{}, value.Len())
+	for i := range elements {
+		elements[i] = value.Index(i).Interface()
+	}
+	return elements, true
+}
+
+// matchEachElement return true if each element is matched by a different matcher
+func matchEachElement(elements []interface{}, matchers []Matcher) bool {
+	elementOfMatcher := make([]int, len(matchers))
+	for i := range elementOfMatcher {
+		elementOfMatcher[i] = -1
+	}
+
+	// assign the element to a free matcher, or to a matcher whose element can be reassigned
+	var assign func(element int, visited []bool) bool
+	assign = func(element int, visited []bool) bool {
+		for m, matcher := range matchers {
+			if visited[m] || !matcher.Matches(elements[element]) {
+				continue
+			}
+			visited[m] = true
+			if elementOfMatcher[m] == -1 || assign(elementOfMatcher[m], visited) {
+				elementOfMatcher[m] = element
+				return true
+			}
+		}
+		return false
+	}
+
+	for element := range elements {
+		if !assign(element, make([]bool, len(matchers))) {
+			return false
+		}
+	}
+	return true
+}
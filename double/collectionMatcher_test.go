@@ -0,0 +1,121 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestCollectionMatcher(t *testing.T) {
+	t.Run("Len", func(t *testing.T) {
+		t.Run("Match the length of slices, arrays, maps, strings and channels", func(t *testing.T) {
+			assert.True(t, Len(3).Matches([]int{1, 2, 3}))
+			assert.True(t, Len(3).Matches([3]string{}))
+			assert.True(t, Len(1).Matches(map[string]int{"a": 1}))
+			assert.True(t, Len(3).Matches("abc"))
+			assert.True(t, Len(0).Matches(make(chan int)))
+			assert.False(t, Len(2).Matches([]int{1, 2, 3}))
+			assert.False(t, Len(0).Matches(nil))
+			assert.False(t, Len(0).Matches(0))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "Len(3)", Len(3).Describe())
+		})
+	})
+
+	t.Run("ContainsElement", func(t *testing.T) {
+		t.Run("Match a slice or an array that contains the element", func(t *testing.T) {
+			assert.True(t, ContainsElement(42).Matches([]int{1, 42}))
+			assert.True(t, ContainsElement(Gt(40)).Matches([2]int{1, 42}))
+			assert.False(t, ContainsElement(42).Matches([]int{1, 2}))
+			assert.False(t, ContainsElement(42).Matches(42))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "ContainsElement(Gt(40))", ContainsElement(Gt(40)).Describe())
+		})
+	})
+
+	t.Run("ElementsMatch", func(t *testing.T) {
+		t.Run("Match the same elements in any order", func(t *testing.T) {
+			assert.True(t, ElementsMatch(3, 1, 2).Matches([]int{1, 2, 3}))
+			assert.True(t, ElementsMatch(1, 1, 2).Matches([]int{1, 2, 1}))
+			assert.False(t, ElementsMatch(1, 2, 2).Matches([]int{1, 2, 1}))
+			assert.False(t, ElementsMatch(1, 2).Matches([]int{1, 2, 3}))
+			assert.False(t, ElementsMatch(1, 2, 3).Matches([]int{1, 2}))
+		})
+
+		t.Run("Match nested matchers", func(t *testing.T) {
+			assert.True(t, ElementsMatch(Gt(0), 1).Matches([]int{5, 1}))
+			assert.True(t, ElementsMatch(Gt(0), 1).Matches([]int{1, 5}))
+			assert.False(t, ElementsMatch(Gt(2), 1).Matches([]int{1, 2}))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "ElementsMatch(1, Gt(0))", ElementsMatch(1, Gt(0)).Describe())
+		})
+	})
+
+	t.Run("SubsetOf", func(t *testing.T) {
+		t.Run("Match the elements that are all among the expected elements", func(t *testing.T) {
+			assert.True(t, SubsetOf(1, 2, 3).Matches([]int{3, 1}))
+			assert.True(t, SubsetOf(1, 2, 3).Matches([]int{}))
+			assert.False(t, SubsetOf(1, 2, 3).Matches([]int{1, 4}))
+			assert.False(t, SubsetOf(1, 2).Matches([]int{1, 1}))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "SubsetOf(1, 2)", SubsetOf(1, 2).Describe())
+		})
+	})
+
+	t.Run("HasKey", func(t *testing.T) {
+		t.Run("Match a map that contains the key", func(t *testing.T) {
+			assert.True(t, HasKey("a").Matches(map[string]int{"a": 1}))
+			assert.True(t, HasKey(HasPrefix("a")).Matches(map[string]int{"abc": 1}))
+			assert.False(t, HasKey("b").Matches(map[string]int{"a": 1}))
+			assert.False(t, HasKey("a").Matches([]string{"a"}))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `HasKey("a")`, HasKey("a").Describe())
+		})
+	})
+
+	t.Run("HasEntry", func(t *testing.T) {
+		t.Run("Match a map that contains the key with the value", func(t *testing.T) {
+			assert.True(t, HasEntry("a", 1).Matches(map[string]int{"a": 1, "b": 2}))
+			assert.True(t, HasEntry("b", Gt(1)).Matches(map[string]int{"a": 1, "b": 2}))
+			assert.False(t, HasEntry("a", 2).Matches(map[string]int{"a": 1, "b": 2}))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `HasEntry("b", Gt(1))`, HasEntry("b", Gt(1)).Describe())
+		})
+	})
+
+	t.Run("Each", func(t *testing.T) {
+		t.Run("Match a slice or an array whose elements all match", func(t *testing.T) {
+			assert.True(t, Each(Gt(0)).Matches([]int{1, 2}))
+			assert.True(t, Each(Gt(0)).Matches([]int{}))
+			assert.True(t, Each("a").Matches([1]string{"a"}))
+			assert.False(t, Each(Gt(0)).Matches([]int{1, 0}))
+			assert.False(t, Each(Gt(0)).Matches(1))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "Each(Gt(0))", Each(Gt(0)).Describe())
+		})
+	})
+
+	t.Run("Use in AssertCalled", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+
+		mock.MethodWithOutArguments([]int{7, 42, 3}, map[string]int{"id": 42})
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithOutArguments", ContainsElement(42), HasEntry("id", 42)))
+	})
+}
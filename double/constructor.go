@@ -20,12 +20,36 @@ func New[T any, TT tester[T]](t TestingT) *T {
 	return result.(*T)
 }
 
+// Wrap is a constructor for partial Stub, Spy and Mock.
+// The calls that match a predefined call return the predefined answer. The other calls are forwarded
+// to the real implementation. A Spy or a Mock still records all the calls.
+//
+//	type OrderRepoSpy struct {
+//		Spy
+//	}
+//	...
+//	func TestExample(t *testing.T) {
+//		spy := Wrap[OrderRepoSpy](t, realRepo)
+//		spy.On("Find", 1).Return(nil, errors.New("not found"))
+//		...
+//	}
+func Wrap[T any, TT wrapper[T]](t TestingT, real interface{}) *T {
+	result := New[T, TT](t)
+	TT(result).Delegate(real)
+	return result
+}
+
 type tester[T any] interface {
 	Test(t TestingT)
 	Caller(c interface{})
 	*T
 }
 
+type wrapper[T any] interface {
+	tester[T]
+	Delegate(real interface{})
+}
+
 // TestingT is an interface wrapper around *testing.T
 type TestingT interface {
 	Logf(format string, args ...interface{})
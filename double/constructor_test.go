@@ -0,0 +1,85 @@
+package double_test
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name        string
+		constructor func(t TestingT, real interface{}) InterfaceTestStub
+	}{
+		{"for stub", func(t TestingT, real interface{}) InterfaceTestStub { return Wrap[StubExample](t, real) }},
+		{"for spy", func(t TestingT, real interface{}) InterfaceTestStub { return Wrap[SpyExample](t, real) }},
+		{"for mock", func(t TestingT, real interface{}) InterfaceTestStub { return Wrap[MockExample](t, real) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("Forward the call without predefined answer to the real implementation", func(t *testing.T) {
+				tt := new(testing.T)
+				stub := test.constructor(tt, RealExample{})
+
+				aInt, err := stub.MethodWithArgumentsAndReturnArguments(123, "123", 123.0)
+
+				assert.Equal(t, 246, aInt)
+				assert.Equal(t, fmt.Errorf("real error"), err)
+			})
+
+			t.Run("Forward the call with a nil error", func(t *testing.T) {
+				tt := new(testing.T)
+				stub := test.constructor(tt, RealExample{})
+
+				aInt, err := stub.MethodWithReturnArguments()
+
+				assert.Equal(t, 42, aInt)
+				assert.Nil(t, err)
+			})
+
+			t.Run("Forward the call without return arguments", func(t *testing.T) {
+				tt := new(testing.T)
+				stub := test.constructor(tt, RealExample{})
+
+				ref := &ExampleType{}
+				stub.MethodWithReferenceArgument(ref)
+
+				assert.True(t, ref.ran)
+			})
+
+			t.Run("Return the predefined answer", func(t *testing.T) {
+				tt := new(testing.T)
+				stub := test.constructor(tt, RealExample{})
+				stub.On("MethodWithArgumentsAndReturnArguments", 1, "1", 1.0).Return(1, nil)
+
+				aInt, err := stub.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+				assert.Equal(t, 1, aInt)
+				assert.Nil(t, err)
+
+				aInt, _ = stub.MethodWithArgumentsAndReturnArguments(2, "2", 2.0)
+				assert.Equal(t, 4, aInt)
+			})
+
+			t.Run("FailNow when the method does not exist in the real implementation", func(t *testing.T) {
+				st := &SpiedTestingT{}
+				stub := test.constructor(st, RealExample{})
+
+				st.AssertFailNowWasCalled(t, func() {
+					stub.Method()
+				})
+				assert.Equal(t, "couldn't forward the call to the real implementation. 'Method' is private or does not exist in double_test.RealExample", st.errorMessages[0])
+			})
+		})
+	}
+
+	t.Run("Record the forwarded calls", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := Wrap[SpyExample](tt, RealExample{})
+
+		_, _ = spy.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+
+		assert.Equal(t, []ActualCall{NewActualCall("MethodWithArgumentsAndReturnArguments", 1, "1", 1.0)}, spy.ActualCalls())
+	})
+}
@@ -0,0 +1,74 @@
+package double
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CtxHasValue matches a context.Context argument that carries the value for the key.
+// The value can be a matcher. A missing key never matches, even with Anything.
+//
+//	Stub.On("Get", CtxHasValue(requestIdKey, "42"), "id")
+func CtxHasValue(key interface{}, value interface{}) Matcher {
+	matcher := matcherOf(value)
+	return contextArgument{
+		description: fmt.Sprintf("CtxHasValue(%#v, %s)", key, matcher.Describe()),
+		accept: func(ctx context.Context) bool {
+			actual := ctx.Value(key)
+			return actual != nil && matcher.Matches(actual)
+		},
+	}
+}
+
+// CtxHasDeadline matches a context.Context argument with a deadline.
+//
+//	Stub.On("Get", CtxHasDeadline(), "id")
+func CtxHasDeadline() Matcher {
+	return contextArgument{
+		description: "CtxHasDeadline()",
+		accept: func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		},
+	}
+}
+
+// CtxDeadlineWithin matches a context.Context argument with a deadline at most d from now.
+//
+//	Stub.On("Get", CtxDeadlineWithin(time.Second), "id")
+func CtxDeadlineWithin(d time.Duration) Matcher {
+	return contextArgument{
+		description: fmt.Sprintf("CtxDeadlineWithin(%s)", d),
+		accept: func(ctx context.Context) bool {
+			deadline, ok := ctx.Deadline()
+			return ok && !deadline.After(time.Now().Add(d))
+		},
+	}
+}
+
+// CtxNotCancelled matches a context.Context argument that is neither cancelled nor past its deadline.
+//
+//	Stub.On("Get", CtxNotCancelled(), "id")
+func CtxNotCancelled() Matcher {
+	return contextArgument{
+		description: "CtxNotCancelled()",
+		accept: func(ctx context.Context) bool {
+			return ctx.Err() == nil
+		},
+	}
+}
+
+type contextArgument struct {
+	description string
+	accept      func(ctx context.Context) bool
+}
+
+func (c contextArgument) Matches(actual interface{}) bool {
+	ctx, ok := actual.(context.Context)
+	return ok && !isNil(ctx) && c.accept(ctx)
+}
+
+func (c contextArgument) Describe() string {
+	return c.description
+}
@@ -0,0 +1,84 @@
+package double_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+type requestIdKey struct{}
+
+type wrappedContext struct {
+	context.Context
+}
+
+func TestContextMatcher(t *testing.T) {
+	t.Run("CtxHasValue", func(t *testing.T) {
+		t.Run("Match a context with the value for the key", func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), requestIdKey{}, "42")
+
+			assert.True(t, CtxHasValue(requestIdKey{}, "42").Matches(ctx))
+			assert.True(t, CtxHasValue(requestIdKey{}, HasPrefix("4")).Matches(ctx))
+			assert.False(t, CtxHasValue(requestIdKey{}, "43").Matches(ctx))
+			assert.False(t, CtxHasValue("other", "42").Matches(ctx))
+			assert.False(t, CtxHasValue("other", Anything).Matches(ctx))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `CtxHasValue(double_test.requestIdKey{}, "42")`, CtxHasValue(requestIdKey{}, "42").Describe())
+		})
+	})
+
+	t.Run("CtxHasDeadline", func(t *testing.T) {
+		t.Run("Match a context with a deadline", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			assert.True(t, CtxHasDeadline().Matches(ctx))
+			assert.False(t, CtxHasDeadline().Matches(context.Background()))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "CtxHasDeadline()", CtxHasDeadline().Describe())
+		})
+	})
+
+	t.Run("CtxDeadlineWithin", func(t *testing.T) {
+		t.Run("Match a context with a deadline at most d from now", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			assert.True(t, CtxDeadlineWithin(time.Hour).Matches(ctx))
+			assert.False(t, CtxDeadlineWithin(time.Second).Matches(ctx))
+			assert.False(t, CtxDeadlineWithin(time.Hour).Matches(context.Background()))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "CtxDeadlineWithin(1s)", CtxDeadlineWithin(time.Second).Describe())
+		})
+	})
+
+	t.Run("CtxNotCancelled", func(t *testing.T) {
+		t.Run("Match a context that is not cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			assert.True(t, CtxNotCancelled().Matches(ctx))
+			cancel()
+			assert.False(t, CtxNotCancelled().Matches(ctx))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "CtxNotCancelled()", CtxNotCancelled().Describe())
+		})
+	})
+
+	t.Run("Don't match other arguments", func(t *testing.T) {
+		assert.False(t, CtxNotCancelled().Matches(nil))
+		assert.False(t, CtxHasValue(requestIdKey{}, Anything).Matches(nil))
+		assert.False(t, CtxHasDeadline().Matches("ctx"))
+		assert.False(t, CtxNotCancelled().Matches((*wrappedContext)(nil)))
+	})
+}
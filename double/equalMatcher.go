@@ -0,0 +1,316 @@
+package double
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EqualWith matches an argument deeply equal to the expected value with the comparison options.
+// Types with an Equal method, like time.Time, are compared with it.
+// When it fails, the differences are described field by field.
+//
+//	Stub.On("Save", EqualWith(expected, IgnoreFields("Id", "Audit.CreatedAt"), NilEqualsEmpty()))
+func EqualWith(expected interface{}, options ...EqualOption) Matcher {
+	e := equalWithArgument{
+		expected:  expected,
+		ignored:   make(map[string]bool),
+		sorters:   make(map[reflect.Type]func(a, b reflect.Value) bool),
+		comparers: make(map[reflect.Type]func(a, b reflect.Value) bool),
+	}
+	for _, option := range options {
+		option(&e)
+	}
+	return e
+}
+
+// EqualOption is an option of EqualWith.
+type EqualOption func(e *equalWithArgument)
+
+// IgnoreFields ignores the fields by path.
+// A path is the field names from the argument separated by dots, without the slice indexes nor the map keys.
+//
+//	EqualWith(expected, IgnoreFields("Id", "Items.CreatedAt"))
+func IgnoreFields(paths ...string) EqualOption {
+	return func(e *equalWithArgument) {
+		for _, path := range paths {
+			e.ignored[path] = true
+		}
+	}
+}
+
+// IgnoreUnexported ignores the unexported fields of the structs.
+func IgnoreUnexported() EqualOption {
+	return func(e *equalWithArgument) {
+		e.ignoreUnexported = true
+	}
+}
+
+// NilEqualsEmpty treats nil and empty slices or maps as equal.
+func NilEqualsEmpty() EqualOption {
+	return func(e *equalWithArgument) {
+		e.nilAsEmpty = true
+	}
+}
+
+// SortSlices sorts the slices of T with less before comparing them.
+// The argument is not modified.
+//
+//	EqualWith(expected, SortSlices(func(a, b int) bool { return a < b }))
+func SortSlices[T any](less func(a, b T) bool) EqualOption {
+	return func(e *equalWithArgument) {
+		e.sorters[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b reflect.Value) bool {
+			return less(a.Interface().(T), b.Interface().(T))
+		}
+	}
+}
+
+// Comparer compares the values of T with equal instead of comparing them deeply.
+//
+//	EqualWith(expected, Comparer(func(a, b float64) bool { return math.Abs(a-b) < 0.01 }))
+func Comparer[T any](equal func(a, b T) bool) EqualOption {
+	return func(e *equalWithArgument) {
+		e.comparers[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b reflect.Value) bool {
+			return equal(a.Interface().(T), b.Interface().(T))
+		}
+	}
+}
+
+type equalWithArgument struct {
+	expected         interface{}
+	ignored          map[string]bool
+	sorters          map[reflect.Type]func(a, b reflect.Value) bool
+	comparers        map[reflect.Type]func(a, b reflect.Value) bool
+	nilAsEmpty       bool
+	ignoreUnexported bool
+}
+
+func (e equalWithArgument) Matches(actual interface{}) bool {
+	return len(e.differences(actual)) == 0
+}
+
+func (e equalWithArgument) Describe() string {
+	return fmt.Sprintf("EqualWith(%#v)", e.expected)
+}
+
+func (e equalWithArgument) describeMismatch(actual interface{}) string {
+	return strings.Join(e.differences(actual), ", ")
+}
+
+func (e equalWithArgument) differences(actual interface{}) []string {
+	d := differ{equalWithArgument: e, visited: make(map[[2]uintptr]bool)}
+	d.compare(reflect.ValueOf(e.expected), reflect.ValueOf(actual), "", "")
+	return d.differences
+}
+
+type differ struct {
+	equalWithArgument
+	visited     map[[2]uintptr]bool
+	differences []string
+}
+
+func (d *differ) report(path string, format string, args ...interface{}) {
+	if path != "" {
+		format = path + ": " + format
+	}
+	d.differences = append(d.differences, fmt.Sprintf(format, args...))
+}
+
+func (d *differ) reportValues(path string, expected reflect.Value, actual reflect.Value) {
+	d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+}
+
+// compare the values deeply. path locates the values for the differences, fieldPath for the ignored fields.
+func (d *differ) compare(expected reflect.Value, actual reflect.Value, path string, fieldPath string) {
+	if !expected.IsValid() || !actual.IsValid() {
+		if expected.IsValid() != actual.IsValid() {
+			d.reportValues(path, expected, actual)
+		}
+		return
+	}
+	if expected.Type() != actual.Type() {
+		d.report(path, "expected type %s, actual type %s", expected.Type(), actual.Type())
+		return
+	}
+	if equal, ok := d.equalFunc(expected.Type()); ok && expected.CanInterface() && actual.CanInterface() {
+		if !equal(expected, actual) {
+			d.reportValues(path, expected, actual)
+		}
+		return
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				d.reportValues(path, expected, actual)
+			}
+			return
+		}
+		if d.alreadyVisited(expected, actual) {
+			return
+		}
+		d.compare(expected.Elem(), actual.Elem(), path, fieldPath)
+	case reflect.Interface:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				d.reportValues(path, expected, actual)
+			}
+			return
+		}
+		d.compare(expected.Elem(), actual.Elem(), path, fieldPath)
+	case reflect.Struct:
+		for i := 0; i < expected.NumField(); i++ {
+			field := expected.Type().Field(i)
+			fieldName := joinPath(fieldPath, field.Name)
+			if d.ignored[fieldName] || d.ignoreUnexported && !field.IsExported() {
+				continue
+			}
+			d.compare(expected.Field(i), actual.Field(i), joinPath(path, field.Name), fieldName)
+		}
+	case reflect.Slice:
+		if d.comparedAsNilOrEmpty(expected, actual, path) {
+			return
+		}
+		if expected.Pointer() == actual.Pointer() && expected.Len() == actual.Len() {
+			return
+		}
+		d.compareElements(d.sorted(expected), d.sorted(actual), path, fieldPath)
+	case reflect.Array:
+		d.compareElements(expected, actual, path, fieldPath)
+	case reflect.Map:
+		if d.comparedAsNilOrEmpty(expected, actual, path) {
+			return
+		}
+		d.compareEntries(expected, actual, path, fieldPath)
+	case reflect.Func:
+		if !expected.IsNil() || !actual.IsNil() {
+			d.reportValues(path, expected, actual)
+		}
+	default:
+		if !equalBasicValues(expected, actual) {
+			d.reportValues(path, expected, actual)
+		}
+	}
+}
+
+func (d *differ) compareElements(expected reflect.Value, actual reflect.Value, path string, fieldPath string) {
+	if expected.Len() != actual.Len() {
+		d.report(path, "expected length %d, actual length %d", expected.Len(), actual.Len())
+		return
+	}
+	for i := 0; i < expected.Len(); i++ {
+		d.compare(expected.Index(i), actual.Index(i), fmt.Sprintf("%s[%d]", path, i), fieldPath)
+	}
+}
+
+func (d *differ) compareEntries(expected reflect.Value, actual reflect.Value, path string, fieldPath string) {
+	for _, key := range sortedKeys(expected) {
+		keyPath := fmt.Sprintf("%s[%s]", path, formatValue(key))
+		actualValue := actual.MapIndex(key)
+		if !actualValue.IsValid() {
+			d.report(keyPath, "missing")
+			continue
+		}
+		d.compare(expected.MapIndex(key), actualValue, keyPath, fieldPath)
+	}
+	for _, key := range sortedKeys(actual) {
+		if !expected.MapIndex(key).IsValid() {
+			d.report(fmt.Sprintf("%s[%s]", path, formatValue(key)), "unexpected")
+		}
+	}
+}
+
+// comparedAsNilOrEmpty compares the slices or maps when one of them is nil or, with NilEqualsEmpty, when both are empty
+func (d *differ) comparedAsNilOrEmpty(expected reflect.Value, actual reflect.Value, path string) bool {
+	if d.nilAsEmpty && expected.Len() == 0 && actual.Len() == 0 {
+		return true
+	}
+	if expected.IsNil() != actual.IsNil() {
+		d.reportValues(path, expected, actual)
+		return true
+	}
+	return false
+}
+
+// alreadyVisited prevents infinite recursion on cyclic values
+func (d *differ) alreadyVisited(expected reflect.Value, actual reflect.Value) bool {
+	key := [2]uintptr{expected.Pointer(), actual.Pointer()}
+	if d.visited[key] {
+		return true
+	}
+	d.visited[key] = true
+	return false
+}
+
+// equalFunc return the comparer of the type or its Equal method
+func (d *differ) equalFunc(valueType reflect.Type) (func(a, b reflect.Value) bool, bool) {
+	if comparer, ok := d.comparers[valueType]; ok {
+		return comparer, true
+	}
+	method, ok := valueType.MethodByName("Equal")
+	if ok && method.Type.NumIn() == 2 && method.Type.In(1) == valueType &&
+		method.Type.NumOut() == 1 && method.Type.Out(0).Kind() == reflect.Bool {
+		return func(a, b reflect.Value) bool {
+			return a.MethodByName("Equal").Call([]reflect.Value{b})[0].Bool()
+		}, true
+	}
+	return nil, false
+}
+
+// sorted return a sorted copy of the slice if SortSlices is set for the type of its elements
+func (d *differ) sorted(slice reflect.Value) reflect.Value {
+	less, ok := d.sorters[slice.Type().Elem()]
+	if !ok || !slice.CanInterface() {
+		return slice
+	}
+	sorted := reflect.MakeSlice(slice.Type(), slice.Len(), slice.Len())
+	reflect.Copy(sorted, slice)
+	sort.SliceStable(sorted.Interface(), func(i, j int) bool {
+		return less(sorted.Index(i), sorted.Index(j))
+	})
+	return sorted
+}
+
+func equalBasicValues(expected reflect.Value, actual reflect.Value) bool {
+	switch expected.Kind() {
+	case reflect.Bool:
+		return expected.Bool() == actual.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return expected.Int() == actual.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return expected.Uint() == actual.Uint()
+	case reflect.Float32, reflect.Float64:
+		return expected.Float() == actual.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return expected.Complex() == actual.Complex()
+	case reflect.String:
+		return expected.String() == actual.String()
+	default:
+		return expected.Pointer() == actual.Pointer()
+	}
+}
+
+// sortedKeys return the keys of the map sorted by their formatted value, so that the differences are stable
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return formatValue(keys[i]) < formatValue(keys[j])
+	})
+	return keys
+}
+
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
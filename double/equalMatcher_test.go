@@ -0,0 +1,126 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+type item struct {
+	Id        string
+	Quantity  int
+	CreatedAt time.Time
+}
+
+type order struct {
+	Id       string
+	Items    []item
+	Tags     []string
+	Metadata map[string]string
+	Next     *order
+	internal int
+}
+
+func TestEqualWithMatcher(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Match deeply equal arguments", func(t *testing.T) {
+		expected := order{Id: "1", Items: []item{{Id: "a", Quantity: 1, CreatedAt: now}}, Metadata: map[string]string{"k": "v"}}
+		actual := order{Id: "1", Items: []item{{Id: "a", Quantity: 1, CreatedAt: now}}, Metadata: map[string]string{"k": "v"}}
+
+		assert.True(t, EqualWith(expected).Matches(actual))
+		assert.True(t, EqualWith(&expected).Matches(&actual))
+		assert.False(t, EqualWith(expected).Matches(&actual))
+		assert.False(t, EqualWith(expected).Matches(nil))
+		assert.True(t, EqualWith(nil).Matches(nil))
+	})
+
+	t.Run("Compare with the Equal method", func(t *testing.T) {
+		assert.True(t, EqualWith(now).Matches(now.In(time.UTC)))
+		assert.False(t, EqualWith(now).Matches(now.Add(time.Second)))
+	})
+
+	t.Run("Compare cyclic values", func(t *testing.T) {
+		expected := &order{Id: "1"}
+		expected.Next = expected
+		actual := &order{Id: "1"}
+		actual.Next = actual
+
+		assert.True(t, EqualWith(expected).Matches(actual))
+	})
+
+	t.Run("IgnoreFields", func(t *testing.T) {
+		expected := order{Id: "1", Items: []item{{Id: "a", CreatedAt: now}}}
+		actual := order{Id: "2", Items: []item{{Id: "a", CreatedAt: now.Add(time.Hour)}}}
+
+		assert.False(t, EqualWith(expected).Matches(actual))
+		assert.False(t, EqualWith(expected, IgnoreFields("Id")).Matches(actual))
+		assert.True(t, EqualWith(expected, IgnoreFields("Id", "Items.CreatedAt")).Matches(actual))
+	})
+
+	t.Run("IgnoreUnexported", func(t *testing.T) {
+		expected := order{Id: "1", internal: 1}
+		actual := order{Id: "1", internal: 2}
+
+		assert.False(t, EqualWith(expected).Matches(actual))
+		assert.True(t, EqualWith(expected, IgnoreUnexported()).Matches(actual))
+	})
+
+	t.Run("NilEqualsEmpty", func(t *testing.T) {
+		expected := order{Id: "1"}
+		actual := order{Id: "1", Tags: []string{}, Metadata: map[string]string{}}
+
+		assert.False(t, EqualWith(expected).Matches(actual))
+		assert.True(t, EqualWith(expected, NilEqualsEmpty()).Matches(actual))
+	})
+
+	t.Run("SortSlices", func(t *testing.T) {
+		actual := []string{"b", "c", "a"}
+
+		assert.False(t, EqualWith([]string{"a", "b", "c"}).Matches(actual))
+		assert.True(t, EqualWith([]string{"a", "b", "c"}, SortSlices(func(a, b string) bool { return a < b })).Matches(actual))
+		assert.Equal(t, []string{"b", "c", "a"}, actual)
+	})
+
+	t.Run("Comparer", func(t *testing.T) {
+		approx := Comparer(func(a, b float64) bool { return math.Abs(a-b) < 0.01 })
+
+		assert.False(t, EqualWith([]float64{1.0}).Matches([]float64{1.001}))
+		assert.True(t, EqualWith([]float64{1.0}, approx).Matches([]float64{1.001}))
+		assert.False(t, EqualWith([]float64{1.0}, approx).Matches([]float64{1.1}))
+	})
+
+	t.Run("Describe", func(t *testing.T) {
+		assert.Equal(t, `EqualWith([]string{"a"})`, EqualWith([]string{"a"}).Describe())
+	})
+
+	t.Run("Log the differences field by field", func(t *testing.T) {
+		spiedT := new(SpiedTestingT)
+		expected := order{Id: "1", Items: []item{{Id: "a", Quantity: 1}}, Metadata: map[string]string{"k": "v", "l": "w"}}
+		actual := order{Id: "2", Items: []item{{Id: "a", Quantity: 2}}, Metadata: map[string]string{"k": "x", "m": "w"}}
+
+		Arguments{EqualWith(expected, IgnoreUnexported())}.Matches(spiedT, actual)
+
+		assert.Len(t, spiedT.logMessages, 1)
+		logMessage := spiedT.logMessages[0]
+		assert.True(t, strings.HasPrefix(logMessage, "\t0: FAIL: "), logMessage)
+		assert.True(t, strings.HasSuffix(logMessage,
+			`: Id: expected "1", actual "2", `+
+				`Items[0].Quantity: expected 1, actual 2, `+
+				`Metadata["k"]: expected "v", actual "x", `+
+				`Metadata["l"]: missing, `+
+				`Metadata["m"]: unexpected`), logMessage)
+	})
+
+	t.Run("Log the different types", func(t *testing.T) {
+		spiedT := new(SpiedTestingT)
+
+		Arguments{EqualWith(1)}.Matches(spiedT, "1")
+
+		assert.Equal(t, []string{`	0: FAIL: (string=1) doesn't match EqualWith(1): expected type int, actual type string`}, spiedT.logMessages)
+	})
+}
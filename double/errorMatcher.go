@@ -0,0 +1,67 @@
+package double
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ErrorIs matches an error argument with the target in its chain (see errors.Is).
+//
+//	Mock.AssertCalled(t, "ReportFailure", ErrorIs(io.EOF))
+func ErrorIs(target error) Matcher {
+	return errorArgument{description: fmt.Sprintf("ErrorIs(%v)", target), accept: func(err error) bool {
+		return errors.Is(err, target)
+	}}
+}
+
+// ErrorAs matches an error argument with an error of type T in its chain (see errors.As).
+// Panics if T is neither an interface nor an error type.
+//
+//	Mock.AssertCalled(t, "ReportFailure", ErrorAs[*fs.PathError]())
+func ErrorAs[T any]() Matcher {
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
+	if targetType.Kind() != reflect.Interface && !targetType.Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+		panic(fmt.Sprintf("assert: error: %s is neither an interface nor an error", targetType))
+	}
+	return errorArgument{description: fmt.Sprintf("ErrorAs[%s]()", targetType), accept: func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}}
+}
+
+// ErrorContains matches an error argument whose message contains the substring.
+//
+//	Mock.AssertCalled(t, "ReportFailure", ErrorContains("timeout"))
+func ErrorContains(substring string) Matcher {
+	return errorArgument{description: fmt.Sprintf("ErrorContains(%q)", substring), accept: func(err error) bool {
+		return strings.Contains(err.Error(), substring)
+	}}
+}
+
+// ErrorMessage matches an error argument whose message matches the regular expression.
+// Panics if the regular expression can't be compiled.
+//
+//	Mock.AssertCalled(t, "ReportFailure", ErrorMessage(`^order \d+ not found$`))
+func ErrorMessage(pattern string) Matcher {
+	compiled := regexp.MustCompile(pattern)
+	return errorArgument{description: fmt.Sprintf("ErrorMessage(%q)", pattern), accept: func(err error) bool {
+		return compiled.MatchString(err.Error())
+	}}
+}
+
+type errorArgument struct {
+	description string
+	accept      func(err error) bool
+}
+
+func (e errorArgument) Matches(actual interface{}) bool {
+	err, ok := actual.(error)
+	return ok && !isNil(err) && e.accept(err)
+}
+
+func (e errorArgument) Describe() string {
+	return e.description
+}
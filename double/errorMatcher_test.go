@@ -0,0 +1,95 @@
+package double_test
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/fs"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+type timeoutError interface {
+	Timeout() bool
+}
+
+type orderError struct {
+	message string
+}
+
+func (o *orderError) Error() string {
+	return o.message
+}
+
+func TestErrorMatcher(t *testing.T) {
+	wrapped := fmt.Errorf("order 42 not found: %w", &fs.PathError{Op: "open", Path: "orders/42", Err: io.EOF})
+
+	t.Run("ErrorIs", func(t *testing.T) {
+		t.Run("Match an error with the target in its chain", func(t *testing.T) {
+			assert.True(t, ErrorIs(io.EOF).Matches(wrapped))
+			assert.True(t, ErrorIs(io.EOF).Matches(io.EOF))
+			assert.False(t, ErrorIs(io.ErrUnexpectedEOF).Matches(wrapped))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "ErrorIs(EOF)", ErrorIs(io.EOF).Describe())
+		})
+	})
+
+	t.Run("ErrorAs", func(t *testing.T) {
+		t.Run("Match an error with an error of the type in its chain", func(t *testing.T) {
+			assert.True(t, ErrorAs[*fs.PathError]().Matches(wrapped))
+			assert.False(t, ErrorAs[*fs.PathError]().Matches(io.EOF))
+		})
+
+		t.Run("Match an error implementing the interface", func(t *testing.T) {
+			assert.True(t, ErrorAs[timeoutError]().Matches(wrapped))
+			assert.False(t, ErrorAs[timeoutError]().Matches(io.EOF))
+		})
+
+		t.Run("Panic if the type is neither an interface nor an error", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: error: int is neither an interface nor an error", func() {
+				ErrorAs[int]()
+			})
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "ErrorAs[*fs.PathError]()", ErrorAs[*fs.PathError]().Describe())
+		})
+	})
+
+	t.Run("ErrorContains", func(t *testing.T) {
+		t.Run("Match an error whose message contains the substring", func(t *testing.T) {
+			assert.True(t, ErrorContains("not found").Matches(wrapped))
+			assert.False(t, ErrorContains("timeout").Matches(wrapped))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `ErrorContains("not found")`, ErrorContains("not found").Describe())
+		})
+	})
+
+	t.Run("ErrorMessage", func(t *testing.T) {
+		t.Run("Match an error whose message matches the regular expression", func(t *testing.T) {
+			assert.True(t, ErrorMessage(`^order \d+ not found`).Matches(wrapped))
+			assert.False(t, ErrorMessage(`^order \d+ created`).Matches(wrapped))
+		})
+
+		t.Run("Panic if the regular expression can't be compiled", func(t *testing.T) {
+			assert.Panics(t, func() { ErrorMessage("(") })
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `ErrorMessage("^order")`, ErrorMessage("^order").Describe())
+		})
+	})
+
+	t.Run("Don't match other arguments", func(t *testing.T) {
+		assert.False(t, ErrorIs(io.EOF).Matches(nil))
+		assert.False(t, ErrorContains("").Matches(nil))
+		assert.False(t, ErrorContains("EOF").Matches("EOF"))
+		assert.False(t, ErrorContains("").Matches((*orderError)(nil)))
+	})
+
+}
@@ -0,0 +1,103 @@
+package double
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fields matches a struct or a pointer to struct argument on the listed exported fields only.
+// The values can be matchers, including nested Fields.
+//
+//	Stub.On("Save", Fields{"Name": "bob", "Age": Gt(18)})
+type Fields map[string]interface{}
+
+func (f Fields) Matches(actual interface{}) bool {
+	value, ok := structOf(actual)
+	if !ok {
+		return false
+	}
+	for name, expected := range f {
+		field, ok := exportedField(value, name)
+		if !ok || !matcherOf(expected).Matches(field) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Fields) Describe() string {
+	return fmt.Sprintf("Fields{%s}", f.describeFields())
+}
+
+// describeFields return the fields sorted by name with their descriptions separated by commas
+func (f Fields) describeFields() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	descriptions := make([]string, len(names))
+	for i, name := range names {
+		descriptions[i] = fmt.Sprintf("%s: %s", name, matcherOf(f[name]).Describe())
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// FieldsOf is the typed variant of Fields. It matches a T or a *T argument on the listed exported fields only.
+// It panics if T is not a struct or if a field is not an exported field of T.
+//
+//	Stub.On("Save", FieldsOf[User](Fields{"Name": "bob", "Age": Gt(18)}))
+func FieldsOf[T any](fields Fields) Matcher {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("assert: fields: %s is not a struct", structType))
+	}
+	for name := range fields {
+		field, ok := structType.FieldByName(name)
+		if !ok || !field.IsExported() {
+			panic(fmt.Sprintf("assert: fields: %s is not an exported field of %s", name, structType))
+		}
+	}
+	return typedFieldsArgument{structType: structType, fields: fields}
+}
+
+type typedFieldsArgument struct {
+	structType reflect.Type
+	fields     Fields
+}
+
+func (t typedFieldsArgument) Matches(actual interface{}) bool {
+	value, ok := structOf(actual)
+	return ok && value.Type() == t.structType && t.fields.Matches(actual)
+}
+
+func (t typedFieldsArgument) Describe() string {
+	return fmt.Sprintf("FieldsOf[%s]{%s}", t.structType, t.fields.describeFields())
+}
+
+// structOf return the struct value of a struct or a non-nil pointer to struct
+func structOf(actual interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(actual)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+		value = value.Elem()
+	}
+	return value, value.Kind() == reflect.Struct
+}
+
+// exportedField return the value of the exported field, promoted fields included
+func exportedField(value reflect.Value, name string) (interface{}, bool) {
+	structField, ok := value.Type().FieldByName(name)
+	if !ok || !structField.IsExported() {
+		return nil, false
+	}
+	field, err := value.FieldByIndexErr(structField.Index)
+	if err != nil {
+		return nil, false
+	}
+	return field.Interface(), true
+}
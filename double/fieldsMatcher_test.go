@@ -0,0 +1,112 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+type address struct {
+	City string
+}
+
+type audit struct {
+	CreatedAt time.Time
+}
+
+type command struct {
+	audit
+	Id      string
+	Name    string
+	Age     int
+	Address *address
+	secret  string
+}
+
+func TestFieldsMatcher(t *testing.T) {
+	aCommand := command{
+		audit:   audit{CreatedAt: time.Now()},
+		Id:      "generated-id",
+		Name:    "bob",
+		Age:     42,
+		Address: &address{City: "Paris"},
+		secret:  "secret",
+	}
+
+	t.Run("Fields", func(t *testing.T) {
+		t.Run("Match the listed fields only", func(t *testing.T) {
+			assert.True(t, Fields{"Name": "bob"}.Matches(aCommand))
+			assert.True(t, Fields{"Name": "bob", "Age": Gt(18)}.Matches(aCommand))
+			assert.False(t, Fields{"Name": "alice"}.Matches(aCommand))
+			assert.False(t, Fields{"Name": "bob", "Age": Lt(18)}.Matches(aCommand))
+		})
+
+		t.Run("Match a pointer to struct", func(t *testing.T) {
+			assert.True(t, Fields{"Name": "bob"}.Matches(&aCommand))
+			assert.False(t, Fields{"Name": "bob"}.Matches((*command)(nil)))
+		})
+
+		t.Run("Match nested fields", func(t *testing.T) {
+			assert.True(t, Fields{"Address": Fields{"City": "Paris"}}.Matches(aCommand))
+			assert.False(t, Fields{"Address": Fields{"City": "London"}}.Matches(aCommand))
+			assert.False(t, Fields{"Address": Fields{"City": "Paris"}}.Matches(command{}))
+		})
+
+		t.Run("Match promoted fields", func(t *testing.T) {
+			assert.True(t, Fields{"CreatedAt": MatchedBy(func(createdAt time.Time) bool { return !createdAt.IsZero() })}.Matches(aCommand))
+		})
+
+		t.Run("Don't match unexported or unknown fields", func(t *testing.T) {
+			assert.False(t, Fields{"secret": "secret"}.Matches(aCommand))
+			assert.False(t, Fields{"Unknown": Anything}.Matches(aCommand))
+		})
+
+		t.Run("Don't match other kinds", func(t *testing.T) {
+			assert.False(t, Fields{}.Matches(nil))
+			assert.False(t, Fields{}.Matches("bob"))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `Fields{Age: Gt(18), Name: "bob"}`, Fields{"Name": "bob", "Age": Gt(18)}.Describe())
+		})
+	})
+
+	t.Run("FieldsOf", func(t *testing.T) {
+		t.Run("Match the listed fields of the type only", func(t *testing.T) {
+			assert.True(t, FieldsOf[command](Fields{"Name": "bob"}).Matches(aCommand))
+			assert.True(t, FieldsOf[command](Fields{"Name": "bob"}).Matches(&aCommand))
+			assert.False(t, FieldsOf[command](Fields{"Name": "alice"}).Matches(aCommand))
+			assert.False(t, FieldsOf[address](Fields{"City": "Paris"}).Matches(aCommand))
+		})
+
+		t.Run("Panic if the type is not a struct", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: fields: int is not a struct", func() {
+				FieldsOf[int](Fields{})
+			})
+		})
+
+		t.Run("Panic if a field is not an exported field", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: fields: secret is not an exported field of double_test.command", func() {
+				FieldsOf[command](Fields{"secret": "secret"})
+			})
+			assert.PanicsWithValue(t, "assert: fields: Unknown is not an exported field of double_test.command", func() {
+				FieldsOf[command](Fields{"Unknown": Anything})
+			})
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `FieldsOf[double_test.command]{Name: "bob"}`, FieldsOf[command](Fields{"Name": "bob"}).Describe())
+		})
+	})
+
+	t.Run("Use in AssertCalled", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+
+		mock.MethodWithReferenceArgument(&ExampleType{})
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithReferenceArgument", FieldsOf[ExampleType](Fields{})))
+	})
+}
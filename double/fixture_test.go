@@ -34,6 +34,29 @@ func (s *StubExample) MethodWithReferenceArgument(ref *ExampleType) {
 	s.Called(ref)
 }
 
+func (s *StubExample) MethodWithOutArguments(aSlice []int, aMap map[string]int) {
+	s.Called(aSlice, aMap)
+}
+
+func (s *StubExample) MethodWithSliceArgument(aSlice []int) int {
+	arguments := s.Called(aSlice)
+	return arguments.Int(0)
+}
+
+func (s *StubExample) MethodWithVariadicArguments(aString string, values ...int) int {
+	arguments := s.Called(aString, values)
+	return arguments.Int(0)
+}
+
+func (s *StubExample) MethodWithCallbackArgument(callback func(aInt int, aString string)) {
+	s.Called(callback)
+}
+
+func (s *StubExample) MethodWithStreamReturnArgument() (<-chan int, error) {
+	arguments := s.Called()
+	return arguments.Get(0).(<-chan int), arguments.Error(1)
+}
+
 func (s *StubExample) privateMethod() error {
 	arguments := s.Called()
 	return arguments.Error(0)
@@ -74,6 +97,29 @@ func (s *SpyExample) MethodWithReferenceArgument(ref *ExampleType) {
 	s.Called(ref)
 }
 
+func (s *SpyExample) MethodWithOutArguments(aSlice []int, aMap map[string]int) {
+	s.Called(aSlice, aMap)
+}
+
+func (s *SpyExample) MethodWithSliceArgument(aSlice []int) int {
+	arguments := s.Called(aSlice)
+	return arguments.Int(0)
+}
+
+func (s *SpyExample) MethodWithVariadicArguments(aString string, values ...int) int {
+	arguments := s.Called(aString, values)
+	return arguments.Int(0)
+}
+
+func (s *SpyExample) MethodWithCallbackArgument(callback func(aInt int, aString string)) {
+	s.Called(callback)
+}
+
+func (s *SpyExample) MethodWithStreamReturnArgument() (<-chan int, error) {
+	arguments := s.Called()
+	return arguments.Get(0).(<-chan int), arguments.Error(1)
+}
+
 func (s *SpyExample) privateMethod() error {
 	arguments := s.Called()
 	return arguments.Error(0)
@@ -123,6 +169,29 @@ func (s *MockExample) MethodWithReferenceArgument(ref *ExampleType) {
 	s.Called(ref)
 }
 
+func (s *MockExample) MethodWithOutArguments(aSlice []int, aMap map[string]int) {
+	s.Called(aSlice, aMap)
+}
+
+func (s *MockExample) MethodWithSliceArgument(aSlice []int) int {
+	arguments := s.Called(aSlice)
+	return arguments.Int(0)
+}
+
+func (s *MockExample) MethodWithVariadicArguments(aString string, values ...int) int {
+	arguments := s.Called(aString, values)
+	return arguments.Int(0)
+}
+
+func (s *MockExample) MethodWithCallbackArgument(callback func(aInt int, aString string)) {
+	s.Called(callback)
+}
+
+func (s *MockExample) MethodWithStreamReturnArgument() (<-chan int, error) {
+	arguments := s.Called()
+	return arguments.Get(0).(<-chan int), arguments.Error(1)
+}
+
 func (s *MockExample) privateMethod() error {
 	arguments := s.Called()
 	return arguments.Error(0)
@@ -179,12 +248,31 @@ func (s *SpiedTestingT) AssertFailNowWasCalled(t *testing.T, f func()) {
 // Check if SpiedTestingT implements all methods of TestingT
 var _ TestingT = (*SpiedTestingT)(nil)
 
+// AsyncSpiedTestingT sends the error messages to a channel, for the errors reported by other goroutines
+type AsyncSpiedTestingT struct {
+	SpiedTestingT
+	errors chan string
+}
+
+func NewAsyncSpiedTestingT() *AsyncSpiedTestingT {
+	return &AsyncSpiedTestingT{errors: make(chan string, 1)}
+}
+
+func (s *AsyncSpiedTestingT) Errorf(format string, args ...interface{}) {
+	s.errors <- fmt.Sprintf(format, args...)
+}
+
 type InterfaceStubExample interface {
 	Method()
 	MethodWithArguments(aInt int, aString string, aFloat float64)
 	MethodWithReturnArguments() (int, error)
 	MethodWithArgumentsAndReturnArguments(aInt int, aString string, aFloat float64) (int, error)
 	MethodWithReferenceArgument(ref *ExampleType)
+	MethodWithOutArguments(aSlice []int, aMap map[string]int)
+	MethodWithSliceArgument(aSlice []int) int
+	MethodWithVariadicArguments(aString string, values ...int) int
+	MethodWithCallbackArgument(callback func(aInt int, aString string))
+	MethodWithStreamReturnArgument() (<-chan int, error)
 	privateMethod() error
 	privateMethodWithMethodCalled(aInt int) error
 }
@@ -212,3 +300,17 @@ type InterfaceTestSpy interface {
 	InterfaceSpyExample
 	ISpy
 }
+
+type RealExample struct{}
+
+func (RealExample) MethodWithReturnArguments() (int, error) {
+	return 42, nil
+}
+
+func (RealExample) MethodWithArgumentsAndReturnArguments(aInt int, _ string, _ float64) (int, error) {
+	return aInt * 2, fmt.Errorf("real error")
+}
+
+func (RealExample) MethodWithReferenceArgument(ref *ExampleType) {
+	ref.ran = true
+}
@@ -32,12 +32,12 @@ func (i *InOrderValidator) AssertCalled(t TestingT, mock IMock, methodName strin
 	call, callExists := i.popCurrentCall()
 
 	if callExists && mock.AssertCalled(t, methodName, arguments...) &&
-		call.matches(t, methodName, arguments) {
+		call.matches(t, methodName, mock.isVariadic(methodName), arguments) {
 
 		i.expectationsCount++
 		return true
 	}
-	t.Errorf("InOrder: %s with arguments %v is not called in right order (expected %d)", methodName, arguments, i.assertCursor)
+	t.Errorf("InOrder: %s with arguments %s is not called in right order (expected %d)", methodName, Arguments(arguments).describe(), i.assertCursor)
 	return false
 }
 
@@ -0,0 +1,115 @@
+package double
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Latency is a model of the time a stubbed method takes to return (see Call.AfterLatency).
+type Latency interface {
+	// Duration draws a duration from the random source
+	Duration(random *rand.Rand) time.Duration
+}
+
+// UniformJitter is a Latency uniformly distributed between base-jitter and base+jitter.
+// A negative duration is considered as 0.
+//
+//	UniformJitter(100*time.Millisecond, 20*time.Millisecond)
+func UniformJitter(base time.Duration, jitter time.Duration) Latency {
+	return uniformLatency{base: base, jitter: jitter}
+}
+
+// NormalLatency is a Latency that follows a normal distribution.
+// A negative duration is considered as 0.
+//
+//	NormalLatency(100*time.Millisecond, 20*time.Millisecond)
+func NormalLatency(mean time.Duration, standardDeviation time.Duration) Latency {
+	return normalLatency{mean: mean, standardDeviation: standardDeviation}
+}
+
+// ExponentialLatency is a Latency that follows an exponential distribution.
+//
+//	ExponentialLatency(100 * time.Millisecond)
+func ExponentialLatency(mean time.Duration) Latency {
+	return exponentialLatency{mean: mean}
+}
+
+// PercentileLatency is a Latency defined by a table of percentiles (between 0 and 100) and their duration.
+// The durations between two percentiles are linearly interpolated.
+// Panics if the table is empty or if a percentile is not between 0 and 100.
+//
+//	PercentileLatency(map[float64]time.Duration{50: 10 * time.Millisecond, 99: 200 * time.Millisecond, 100: time.Second})
+func PercentileLatency(percentiles map[float64]time.Duration) Latency {
+	if len(percentiles) == 0 {
+		panic("assert: latency: the table of percentiles is empty")
+	}
+
+	result := percentileLatency{}
+	for percentile, duration := range percentiles {
+		if percentile < 0 || percentile > 100 {
+			panic(fmt.Sprintf("assert: latency: the percentile %v is not between 0 and 100", percentile))
+		}
+		result = append(result, percentileDuration{percentile: percentile, duration: duration})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].percentile < result[j].percentile })
+	return result
+}
+
+type uniformLatency struct {
+	base   time.Duration
+	jitter time.Duration
+}
+
+func (u uniformLatency) Duration(random *rand.Rand) time.Duration {
+	jitter := time.Duration((random.Float64()*2 - 1) * float64(u.jitter))
+	return positive(u.base + jitter)
+}
+
+type normalLatency struct {
+	mean              time.Duration
+	standardDeviation time.Duration
+}
+
+func (n normalLatency) Duration(random *rand.Rand) time.Duration {
+	return positive(n.mean + time.Duration(random.NormFloat64()*float64(n.standardDeviation)))
+}
+
+type exponentialLatency struct {
+	mean time.Duration
+}
+
+func (e exponentialLatency) Duration(random *rand.Rand) time.Duration {
+	return time.Duration(random.ExpFloat64() * float64(e.mean))
+}
+
+type percentileDuration struct {
+	percentile float64
+	duration   time.Duration
+}
+
+type percentileLatency []percentileDuration
+
+func (p percentileLatency) Duration(random *rand.Rand) time.Duration {
+	percentile := random.Float64() * 100
+	if percentile <= p[0].percentile {
+		return p[0].duration
+	}
+
+	for i := 1; i < len(p); i++ {
+		if percentile <= p[i].percentile {
+			lower, upper := p[i-1], p[i]
+			ratio := (percentile - lower.percentile) / (upper.percentile - lower.percentile)
+			return lower.duration + time.Duration(ratio*float64(upper.duration-lower.duration))
+		}
+	}
+	return p[len(p)-1].duration
+}
+
+func positive(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+	return duration
+}
@@ -0,0 +1,112 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestLatency(t *testing.T) {
+	draw := func(latency Latency, seed int64, n int) []time.Duration {
+		random := rand.New(rand.NewSource(seed))
+		var durations []time.Duration
+		for i := 0; i < n; i++ {
+			durations = append(durations, latency.Duration(random))
+		}
+		return durations
+	}
+
+	t.Run("UniformJitter", func(t *testing.T) {
+		t.Run("Draw durations between base-jitter and base+jitter", func(t *testing.T) {
+			for _, duration := range draw(UniformJitter(100*time.Millisecond, 20*time.Millisecond), 1, 1000) {
+				assert.GreaterOrEqual(t, duration, 80*time.Millisecond)
+				assert.LessOrEqual(t, duration, 120*time.Millisecond)
+			}
+		})
+
+		t.Run("Draw positive durations", func(t *testing.T) {
+			for _, duration := range draw(UniformJitter(time.Millisecond, 20*time.Millisecond), 1, 1000) {
+				assert.GreaterOrEqual(t, duration, time.Duration(0))
+			}
+		})
+	})
+
+	t.Run("NormalLatency", func(t *testing.T) {
+		t.Run("Draw durations around the mean", func(t *testing.T) {
+			durations := draw(NormalLatency(100*time.Millisecond, 10*time.Millisecond), 1, 1000)
+
+			assert.InDelta(t, float64(100*time.Millisecond), float64(mean(durations)), float64(2*time.Millisecond))
+		})
+
+		t.Run("Draw positive durations", func(t *testing.T) {
+			for _, duration := range draw(NormalLatency(time.Millisecond, 20*time.Millisecond), 1, 1000) {
+				assert.GreaterOrEqual(t, duration, time.Duration(0))
+			}
+		})
+	})
+
+	t.Run("ExponentialLatency", func(t *testing.T) {
+		t.Run("Draw durations around the mean", func(t *testing.T) {
+			durations := draw(ExponentialLatency(100*time.Millisecond), 1, 10000)
+
+			assert.InDelta(t, float64(100*time.Millisecond), float64(mean(durations)), float64(5*time.Millisecond))
+		})
+	})
+
+	t.Run("PercentileLatency", func(t *testing.T) {
+		t.Run("Draw durations following the percentiles", func(t *testing.T) {
+			latency := PercentileLatency(map[float64]time.Duration{50: 10 * time.Millisecond, 99: 100 * time.Millisecond, 100: time.Second})
+
+			durations := draw(latency, 1, 10000)
+
+			belowMedian := 0
+			for _, duration := range durations {
+				assert.GreaterOrEqual(t, duration, 10*time.Millisecond)
+				assert.LessOrEqual(t, duration, time.Second)
+				if duration == 10*time.Millisecond {
+					belowMedian++
+				}
+			}
+			assert.InDelta(t, 5000, belowMedian, 200)
+		})
+
+		t.Run("Interpolate between percentiles", func(t *testing.T) {
+			latency := PercentileLatency(map[float64]time.Duration{0: 0, 100: 100 * time.Millisecond})
+
+			for _, duration := range draw(latency, 1, 1000) {
+				assert.LessOrEqual(t, duration, 100*time.Millisecond)
+			}
+			assert.InDelta(t, float64(50*time.Millisecond), float64(mean(draw(latency, 1, 10000))), float64(2*time.Millisecond))
+		})
+
+		t.Run("Panic when the table is empty", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: latency: the table of percentiles is empty", func() {
+				PercentileLatency(map[float64]time.Duration{})
+			})
+		})
+
+		t.Run("Panic when a percentile is not between 0 and 100", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: latency: the percentile 101 is not between 0 and 100", func() {
+				PercentileLatency(map[float64]time.Duration{101: time.Second})
+			})
+		})
+	})
+
+	t.Run("Same seed draws same durations", func(t *testing.T) {
+		latency := NormalLatency(100*time.Millisecond, 10*time.Millisecond)
+
+		assert.Equal(t, draw(latency, 42, 10), draw(latency, 42, 10))
+		assert.NotEqual(t, draw(latency, 42, 10), draw(latency, 43, 10))
+	})
+}
+
+func mean(durations []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, duration := range durations {
+		sum += duration
+	}
+	return sum / time.Duration(len(durations))
+}
@@ -0,0 +1,73 @@
+package double
+
+import "fmt"
+
+// And matches an argument matched by all the expected arguments.
+// The expected arguments can be values or matchers.
+//
+//	Stub.On("Method", And(IsType(0), Not(0)))
+func And(expected ...interface{}) Matcher {
+	return logicalArgument{name: "And", matchers: matchersOf(expected), accept: func(matched, total int) bool {
+		return matched == total
+	}}
+}
+
+// Or matches an argument matched by at least one of the expected arguments.
+// The expected arguments can be values or matchers.
+//
+//	Stub.On("Method", Or(200, 204))
+func Or(expected ...interface{}) Matcher {
+	return logicalArgument{name: "Or", matchers: matchersOf(expected), accept: atLeastOne}
+}
+
+// Not matches an argument that is not matched by the expected argument.
+// The expected argument can be a value or a matcher.
+//
+//	Stub.On("Method", Not("admin"))
+func Not(expected interface{}) Matcher {
+	return logicalArgument{name: "Not", matchers: matchersOf([]interface{}{expected}), accept: none}
+}
+
+// AnyOf matches an argument equal to one of the values.
+// The values can be matchers.
+//
+//	Stub.On("Method", AnyOf(200, 204))
+func AnyOf(values ...interface{}) Matcher {
+	return logicalArgument{name: "AnyOf", matchers: matchersOf(values), accept: atLeastOne}
+}
+
+// NoneOf matches an argument equal to none of the values.
+// The values can be matchers.
+//
+//	Stub.On("Method", NoneOf("admin", "root"))
+func NoneOf(values ...interface{}) Matcher {
+	return logicalArgument{name: "NoneOf", matchers: matchersOf(values), accept: none}
+}
+
+type logicalArgument struct {
+	name     string
+	matchers []Matcher
+	accept   func(matched int, total int) bool
+}
+
+func (l logicalArgument) Matches(actual interface{}) bool {
+	matched := 0
+	for _, matcher := range l.matchers {
+		if matcher.Matches(actual) {
+			matched++
+		}
+	}
+	return l.accept(matched, len(l.matchers))
+}
+
+func (l logicalArgument) Describe() string {
+	return fmt.Sprintf("%s(%s)", l.name, describeAll(l.matchers))
+}
+
+func atLeastOne(matched int, _ int) bool {
+	return matched > 0
+}
+
+func none(matched int, _ int) bool {
+	return matched == 0
+}
@@ -0,0 +1,110 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestLogicalMatcher(t *testing.T) {
+	t.Run("And", func(t *testing.T) {
+		t.Run("Match when all the expected arguments match", func(t *testing.T) {
+			matcher := And(IsType(0), Not(0))
+
+			assert.True(t, matcher.Matches(1))
+			assert.False(t, matcher.Matches(0))
+			assert.False(t, matcher.Matches("1"))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "And(IsType(int), Not(0))", And(IsType(0), Not(0)).Describe())
+		})
+	})
+
+	t.Run("Or", func(t *testing.T) {
+		t.Run("Match when one of the expected arguments matches", func(t *testing.T) {
+			matcher := Or(200, AnythingOfType("string"))
+
+			assert.True(t, matcher.Matches(200))
+			assert.True(t, matcher.Matches("200"))
+			assert.False(t, matcher.Matches(204))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `Or(200, AnythingOfType(string))`, Or(200, AnythingOfType("string")).Describe())
+		})
+	})
+
+	t.Run("Not", func(t *testing.T) {
+		t.Run("Match when the expected argument doesn't match", func(t *testing.T) {
+			matcher := Not("admin")
+
+			assert.True(t, matcher.Matches("bob"))
+			assert.False(t, matcher.Matches("admin"))
+		})
+
+		t.Run("Match nothing with Anything", func(t *testing.T) {
+			assert.False(t, Not(Anything).Matches("bob"))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `Not("admin")`, Not("admin").Describe())
+			assert.Equal(t, `Not(Anything)`, Not(Anything).Describe())
+		})
+	})
+
+	t.Run("AnyOf", func(t *testing.T) {
+		t.Run("Match when the argument is one of the values", func(t *testing.T) {
+			matcher := AnyOf(200, 204)
+
+			assert.True(t, matcher.Matches(200))
+			assert.True(t, matcher.Matches(204))
+			assert.False(t, matcher.Matches(404))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "AnyOf(200, 204)", AnyOf(200, 204).Describe())
+		})
+	})
+
+	t.Run("NoneOf", func(t *testing.T) {
+		t.Run("Match when the argument is none of the values", func(t *testing.T) {
+			matcher := NoneOf("admin", "root")
+
+			assert.True(t, matcher.Matches("bob"))
+			assert.False(t, matcher.Matches("admin"))
+			assert.False(t, matcher.Matches("root"))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `NoneOf("admin", "root")`, NoneOf("admin", "root").Describe())
+		})
+	})
+
+	t.Run("Use in On", func(t *testing.T) {
+		tt := new(testing.T)
+		stub := New[StubExample](tt)
+		stub.On("MethodWithArgumentsAndReturnArguments", Or(200, 204), Not("admin"), Anything).Return(1, nil)
+		stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(2, nil)
+
+		aInt, _ := stub.MethodWithArgumentsAndReturnArguments(204, "bob", 1.0)
+		assert.Equal(t, 1, aInt)
+
+		aInt, _ = stub.MethodWithArgumentsAndReturnArguments(204, "admin", 1.0)
+		assert.Equal(t, 2, aInt)
+	})
+
+	t.Run("Use in AssertCalled and NumberOfCallsWithArguments", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+
+		mock.MethodWithOneArgument(200)
+		mock.MethodWithOneArgument(204)
+		mock.MethodWithOneArgument(404)
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithOneArgument", AnyOf(200, 204)))
+		assert.Equal(t, 2, mock.NumberOfCallsWithArguments("MethodWithOneArgument", AnyOf(200, 204)))
+		assert.Equal(t, 1, mock.NumberOfCallsWithArguments("MethodWithOneArgument", NoneOf(200, 204)))
+	})
+}
@@ -36,7 +36,7 @@ func (m *Mock) MethodCalled(methodInformation MethodInformation, arguments ...in
 func (m *Mock) AddActualCall(arguments ...interface{}) {
 	functionName := GetCallingFunctionName(2)
 	m.recordCallInOrder(functionName, arguments...)
-	m.actualCalls.append(functionName, arguments)
+	m.recordActualCall(functionName, arguments)
 }
 
 // AssertNumberOfCalls asserts that the method was called expectedCalls times.
@@ -58,11 +58,26 @@ func (m *Mock) AssertNumberOfCallsWithArguments(t TestingT, expectedCalls int, m
 }
 
 // AssertCalled asserts that the method was called.
-// It can produce a false result when an argument is a pointer type and the underlying value changed after calling the mocked method.
+// It can produce a false result when an argument is a pointer type and the underlying value changed after calling the mocked method,
+// unless the arguments are snapshotted (see Spy.SnapshotArguments).
 func (m *Mock) AssertCalled(t TestingT, methodName string, arguments ...interface{}) bool {
 	t.Helper()
 
-	numberOfCalls := m.NumberOfCallsWithArguments(methodName, arguments...)
+	return m.assertCalledWithArguments(t, methodName, arguments, m.NumberOfCallsWithArguments(methodName, arguments...))
+}
+
+// assertCalledWithArguments fails with the actual calls of the method if numberOfCalls is 0
+func (m *Mock) assertCalledWithArguments(t TestingT, methodName string, arguments Arguments, numberOfCalls int) bool {
+	t.Helper()
+
+	return m.assertCalled(t, "Should have called with given arguments", methodName, "with:\n"+arguments.describe(), numberOfCalls)
+}
+
+// assertCalled fails with the actual calls of the method if numberOfCalls is 0.
+// The expectation describes the expected arguments.
+func (m *Mock) assertCalled(t TestingT, failure string, methodName string, expectation string, numberOfCalls int) bool {
+	t.Helper()
+
 	if numberOfCalls == 0 {
 		var calledWithArgs []string
 		for _, call := range m.ActualCalls() {
@@ -72,42 +87,83 @@ func (m *Mock) AssertCalled(t TestingT, methodName string, arguments ...interfac
 		}
 
 		if len(calledWithArgs) == 0 {
-			return assert.Fail(t, "Should have called with given arguments",
-				fmt.Sprintf("Expected %q to have been called with:\n%v\nbut no actual calls happened", methodName, arguments))
+			return assert.Fail(t, failure, fmt.Sprintf("Expected %q to have been called %s\nbut no actual calls happened", methodName, expectation))
 		}
 
-		return assert.Fail(t, "Should have called with given arguments", fmt.Sprintf("Expected %q to have been called with:\n%v\nbut actual calls were:\n        %v", methodName, arguments, strings.Join(calledWithArgs, "\n")))
+		return assert.Fail(t, failure, fmt.Sprintf("Expected %q to have been called %s\nbut actual calls were:\n        %v", methodName, expectation, strings.Join(calledWithArgs, "\n")))
 	}
 
 	return true
 }
 
 // AssertNotCalled asserts that the method was not called.
-// It can produce a false result when an argument is a pointer type and the underlying value changed after calling the mocked method.
+// It can produce a false result when an argument is a pointer type and the underlying value changed after calling the mocked method,
+// unless the arguments are snapshotted (see Spy.SnapshotArguments).
 func (m *Mock) AssertNotCalled(t TestingT, methodName string, arguments ...interface{}) bool {
 	t.Helper()
 
-	numberOfCalls := m.NumberOfCallsWithArguments(methodName, arguments...)
+	// The calls that should not have happened are not recorded by the Captor and Ref matchers
+	numberOfCalls := m.numberOfCallsWithArguments(methodName, arguments, false)
 
 	if numberOfCalls > 0 {
 		return assert.Fail(t, "Should not have called with given arguments",
-			fmt.Sprintf("Expected %q to not have been called with:\n%v\nbut actually it was.", methodName, arguments))
+			fmt.Sprintf("Expected %q to not have been called with:\n%s\nbut actually it was.", methodName, Arguments(arguments).describe()))
+	}
+	return true
+}
+
+// AssertCalledMatching asserts that the method was called with a whole list of arguments matching the predicate.
+//
+//	Mock.AssertCalledMatching(t, "Range", func(args Arguments) bool { return args.Int(0) < args.Int(1) })
+func (m *Mock) AssertCalledMatching(t TestingT, methodName string, predicate func(arguments Arguments) bool) bool {
+	t.Helper()
+
+	numberOfCalls := m.NumberOfCallsMatching(methodName, predicate)
+
+	return m.assertCalled(t, "Should have called with arguments matching the predicate", methodName, "with arguments matching the predicate", numberOfCalls)
+}
+
+// AssertNotCalledMatching asserts that the method was not called with a whole list of arguments matching the predicate.
+func (m *Mock) AssertNotCalledMatching(t TestingT, methodName string, predicate func(arguments Arguments) bool) bool {
+	t.Helper()
+
+	numberOfCalls := m.NumberOfCallsMatching(methodName, predicate)
+
+	if numberOfCalls > 0 {
+		return assert.Fail(t, "Should not have called with arguments matching the predicate",
+			fmt.Sprintf("Expected %q to not have been called with arguments matching the predicate\nbut actually it was.", methodName))
 	}
 	return true
 }
 
 // AssertExpectations asserts that everything specified with On and Return was
 // in fact called as expected.  Calls may have occurred in any order.
+// The default answers (see Stub.Default) are not expectations and are ignored.
 // Deprecated: to respect the 'Arrange, Act, Assert' pattern, consider using the Assert* methods instead
 func (m *Mock) AssertExpectations(t TestingT) bool {
 	t.Helper()
 
 	result := true
 	for _, call := range m.PredefinedCalls() {
-		expected := m.AssertCalled(t, call.MethodName, call.Arguments...)
+		if call.isDefault {
+			continue
+		}
+		var expected bool
+		if call.predicate != nil {
+			expected = m.AssertCalledMatching(t, call.MethodName, call.predicate)
+		} else {
+			// The arguments were already recorded by the Captor and Ref matchers when the call was selected
+			numberOfCalls := m.numberOfCallsWithArguments(call.MethodName, call.Arguments, false)
+			expected = m.assertCalledWithArguments(t, call.MethodName, call.Arguments, numberOfCalls)
+		}
 		if expected && !call.calledPredefinedTimes() {
-			expected = assert.Fail(t, "Should have called with given arguments",
-				fmt.Sprintf("Expected %q to have been called %d times with:\n%v\nbut actually it was called %d times.", call.MethodName, call.times, call.Arguments, call.totalCalls))
+			if call.predicate != nil {
+				expected = assert.Fail(t, "Should have called with arguments matching the predicate",
+					fmt.Sprintf("Expected %q to have been called %d times with arguments matching the predicate\nbut actually it was called %d times.", call.MethodName, call.times, call.totalCalls))
+			} else {
+				expected = assert.Fail(t, "Should have called with given arguments",
+					fmt.Sprintf("Expected %q to have been called %d times with:\n%s\nbut actually it was called %d times.", call.MethodName, call.times, call.Arguments.describe(), call.totalCalls))
+			}
 		}
 
 		result = result && expected
@@ -122,7 +178,7 @@ func (m *Mock) inOrder(inOrderValidator *InOrderValidator) {
 
 func (m *Mock) recordCallInOrder(methodName string, arguments ...interface{}) {
 	if m.inOrderValidator != nil {
-		call := NewActualCall(methodName, arguments...)
+		call := NewActualCall(methodName, m.recordedArguments(arguments)...)
 		m.inOrderValidator.addCall(call)
 	}
 }
@@ -133,7 +189,10 @@ type IMock interface {
 	AssertNumberOfCallsWithArguments(t TestingT, expectedCalls int, methodName string, arguments ...interface{}) bool
 	AssertCalled(t TestingT, methodName string, arguments ...interface{}) bool
 	AssertNotCalled(t TestingT, methodName string, arguments ...interface{}) bool
+	AssertCalledMatching(t TestingT, methodName string, predicate func(arguments Arguments) bool) bool
+	AssertNotCalledMatching(t TestingT, methodName string, predicate func(arguments Arguments) bool) bool
 	inOrder(inOrder *InOrderValidator)
+	isVariadic(methodName string) bool
 }
 
 // Check if Mock implements all methods of IMock
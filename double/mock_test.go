@@ -164,6 +164,26 @@ func TestMock(t *testing.T) {
 			assert.Len(t, st.errorMessages, 1)
 			assert.Contains(t, st.errorMessages[0], "Should have called with given arguments\n\tMessages:   \tExpected \"MethodWithArguments\" to have been called with:\n\t            \t[1 1 1]\n\t            \tbut actual calls were:\n\t            \t        [2 1 1]\n\t            \t[1 3 1.2]\n")
 		})
+
+		t.Run("Match the variadic arguments flattened or not", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.On("MethodWithVariadicArguments", "msg", Rest(Anything)).Return(0)
+			mock.MethodWithVariadicArguments("msg", 1, 2)
+
+			assert.True(t, mock.AssertCalled(tt, "MethodWithVariadicArguments", "msg", 1, 2))
+			assert.True(t, mock.AssertCalled(tt, "MethodWithVariadicArguments", "msg", []int{1, 2}))
+		})
+
+		t.Run("Don't flatten the slice argument of a method that is not variadic", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.On("MethodWithSliceArgument", Anything).Return(0)
+			mock.MethodWithSliceArgument([]int{1, 2})
+
+			assert.False(t, mock.AssertCalled(tt, "MethodWithSliceArgument", 1, 2))
+			assert.True(t, mock.AssertCalled(tt, "MethodWithSliceArgument", []int{1, 2}))
+		})
 	})
 
 	t.Run("AssertNotCalled", func(t *testing.T) {
@@ -238,6 +258,109 @@ func TestMock(t *testing.T) {
 		})
 	})
 
+	t.Run("SnapshotArguments", func(t *testing.T) {
+		t.Run("Assert on the arguments as they were at call time", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.SnapshotArguments()
+			buffer := []int{1}
+
+			mock.MethodWithOutArguments(buffer, nil)
+			buffer[0] = 2
+			mock.MethodWithOutArguments(buffer, nil)
+
+			assert.True(t, mock.AssertCalled(tt, "MethodWithOutArguments", []int{1}, Anything))
+			assert.Equal(t, 1, mock.NumberOfCallsWithArguments("MethodWithOutArguments", []int{2}, Anything))
+		})
+
+		t.Run("Assert in order on the arguments as they were at call time", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.SnapshotArguments()
+			inOrder := InOrder(mock)
+			buffer := []int{1}
+
+			mock.MethodWithOutArguments(buffer, nil)
+			buffer[0] = 2
+			mock.MethodWithOutArguments(buffer, nil)
+
+			assert.True(t, inOrder.AssertCalled(tt, mock, "MethodWithOutArguments", []int{1}, Anything))
+			assert.True(t, inOrder.AssertCalled(tt, mock, "MethodWithOutArguments", []int{2}, Anything))
+		})
+	})
+
+	t.Run("AssertCalledMatching", func(t *testing.T) {
+		lowerThanFloat := func(args Arguments) bool { return float64(args.Int(0)) < args.Get(2).(float64) }
+
+		t.Run("t.Helper is called", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			mock := New[MockExample](st)
+
+			mock.AssertCalledMatching(st, "MethodWithArguments", lowerThanFloat)
+
+			assert.True(t, st.helperCalled)
+		})
+
+		t.Run("Return true when method is called with arguments matching the predicate", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.MethodWithArguments(1, "2", 3.0)
+
+			result := mock.AssertCalledMatching(tt, "MethodWithArguments", lowerThanFloat)
+
+			assert.True(t, result)
+		})
+
+		t.Run("t.Errorf is called with right message when method is not called", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			mock := New[MockExample](st)
+
+			result := mock.AssertCalledMatching(st, "MethodWithArguments", lowerThanFloat)
+
+			assert.False(t, result)
+			assert.Len(t, st.errorMessages, 1)
+			assert.Contains(t, st.errorMessages[0], "Should have called with arguments matching the predicate\n\tMessages:   \tExpected \"MethodWithArguments\" to have been called with arguments matching the predicate\n\t            \tbut no actual calls happened\n")
+		})
+
+		t.Run("t.Errorf is called with right message when method is called with other arguments", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			mock := New[MockExample](st)
+			mock.MethodWithArguments(4, "2", 3.0)
+
+			result := mock.AssertCalledMatching(st, "MethodWithArguments", lowerThanFloat)
+
+			assert.False(t, result)
+			assert.Len(t, st.errorMessages, 1)
+			assert.Contains(t, st.errorMessages[0], "Expected \"MethodWithArguments\" to have been called with arguments matching the predicate\n\t            \tbut actual calls were:\n\t            \t        [4 2 3]\n")
+		})
+	})
+
+	t.Run("AssertNotCalledMatching", func(t *testing.T) {
+		lowerThanFloat := func(args Arguments) bool { return float64(args.Int(0)) < args.Get(2).(float64) }
+
+		t.Run("Return true when method is called with other arguments", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.MethodWithArguments(4, "2", 3.0)
+
+			result := mock.AssertNotCalledMatching(tt, "MethodWithArguments", lowerThanFloat)
+
+			assert.True(t, result)
+		})
+
+		t.Run("t.Errorf is called with right message when method is called with arguments matching the predicate", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			mock := New[MockExample](st)
+			mock.MethodWithArguments(1, "2", 3.0)
+
+			result := mock.AssertNotCalledMatching(st, "MethodWithArguments", lowerThanFloat)
+
+			assert.False(t, result)
+			assert.Len(t, st.errorMessages, 1)
+			assert.Contains(t, st.errorMessages[0], "Should not have called with arguments matching the predicate\n\tMessages:   \tExpected \"MethodWithArguments\" to not have been called with arguments matching the predicate\n\t            \tbut actually it was.\n")
+		})
+	})
+
 	t.Run("AssertExpectations", func(t *testing.T) {
 		t.Run("t.Helper is called", func(t *testing.T) {
 			st := &SpiedTestingT{}
@@ -306,6 +429,42 @@ func TestMock(t *testing.T) {
 			assert.Len(t, st.errorMessages, 1)
 			assert.Contains(t, st.errorMessages[0], "Should have called with given arguments\n\tMessages:   \tExpected \"MethodWithArgumentsAndReturnArguments\" to have been called 2 times with:\n\t            \t[123 123 123]\n\t            \tbut actually it was called 1 times.\n")
 		})
+
+		t.Run("Ignore the default answers", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.Default("MethodWithReturnArguments").Return(1, nil)
+
+			result := mock.AssertExpectations(tt)
+
+			assert.True(t, result)
+		})
+
+		t.Run("Check the predicates", func(t *testing.T) {
+			tt := new(testing.T)
+			mock := New[MockExample](tt)
+			mock.OnMatch("MethodWithArgumentsAndReturnArguments", func(args Arguments) bool { return args.Int(0) > 0 }).Return(1, nil)
+			mock.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(0, nil)
+
+			_, _ = mock.MethodWithArgumentsAndReturnArguments(0, "", 0)
+			assert.False(t, mock.AssertExpectations(tt))
+
+			_, _ = mock.MethodWithArgumentsAndReturnArguments(1, "", 0)
+			assert.True(t, mock.AssertExpectations(tt))
+		})
+
+		t.Run("Return false when the predicate expectation is not called enough times", func(t *testing.T) {
+			st := &SpiedTestingT{}
+			mock := New[MockExample](st)
+			mock.OnMatch("MethodWithArgumentsAndReturnArguments", func(args Arguments) bool { return args.Int(0) > 0 }).Return(1, nil).Times(2)
+
+			_, _ = mock.MethodWithArgumentsAndReturnArguments(1, "", 0)
+			result := mock.AssertExpectations(st)
+
+			assert.False(t, result)
+			assert.Len(t, st.errorMessages, 1)
+			assert.Contains(t, st.errorMessages[0], "Should have called with arguments matching the predicate\n\tMessages:   \tExpected \"MethodWithArgumentsAndReturnArguments\" to have been called 2 times with arguments matching the predicate\n\t            \tbut actually it was called 1 times.\n")
+		})
 	})
 
 	t.Run("Race condition", func(t *testing.T) {
@@ -0,0 +1,202 @@
+package double
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Gt matches an argument greater than the value.
+// It works across all numeric kinds, time.Time and time.Duration.
+//
+//	Stub.On("Method", Gt(18))
+func Gt(value interface{}) Matcher {
+	return orderingArgument{name: "Gt", value: value, accept: func(comparison int) bool { return comparison > 0 }}
+}
+
+// Gte matches an argument greater than or equal to the value.
+// It works across all numeric kinds, time.Time and time.Duration.
+//
+//	Stub.On("Method", Gte(18))
+func Gte(value interface{}) Matcher {
+	return orderingArgument{name: "Gte", value: value, accept: func(comparison int) bool { return comparison >= 0 }}
+}
+
+// Lt matches an argument less than the value.
+// It works across all numeric kinds, time.Time and time.Duration.
+//
+//	Stub.On("Method", Lt(time.Second))
+func Lt(value interface{}) Matcher {
+	return orderingArgument{name: "Lt", value: value, accept: func(comparison int) bool { return comparison < 0 }}
+}
+
+// Lte matches an argument less than or equal to the value.
+// It works across all numeric kinds, time.Time and time.Duration.
+//
+//	Stub.On("Method", Lte(time.Second))
+func Lte(value interface{}) Matcher {
+	return orderingArgument{name: "Lte", value: value, accept: func(comparison int) bool { return comparison <= 0 }}
+}
+
+// Between matches an argument between low and high (inclusive).
+// It works across all numeric kinds, time.Time and time.Duration.
+//
+//	Stub.On("Method", Between(1.5, 2.5))
+func Between(low interface{}, high interface{}) Matcher {
+	return betweenArgument{low: low, high: high}
+}
+
+// ApproxEqual matches an argument that differs from the value by at most epsilon.
+// It works across all numeric kinds, time.Duration, and time.Time with a time.Duration epsilon.
+//
+//	Stub.On("Method", ApproxEqual(19.99, 0.001))
+func ApproxEqual(value interface{}, epsilon interface{}) Matcher {
+	return approxEqualArgument{value: value, epsilon: epsilon}
+}
+
+type orderingArgument struct {
+	name   string
+	value  interface{}
+	accept func(comparison int) bool
+}
+
+func (o orderingArgument) Matches(actual interface{}) bool {
+	comparison, ok := compare(actual, o.value)
+	return ok && o.accept(comparison)
+}
+
+func (o orderingArgument) Describe() string {
+	return fmt.Sprintf("%s(%v)", o.name, o.value)
+}
+
+type betweenArgument struct {
+	low  interface{}
+	high interface{}
+}
+
+func (b betweenArgument) Matches(actual interface{}) bool {
+	lowComparison, lowOk := compare(actual, b.low)
+	highComparison, highOk := compare(actual, b.high)
+	return lowOk && highOk && lowComparison >= 0 && highComparison <= 0
+}
+
+func (b betweenArgument) Describe() string {
+	return fmt.Sprintf("Between(%v, %v)", b.low, b.high)
+}
+
+type approxEqualArgument struct {
+	value   interface{}
+	epsilon interface{}
+}
+
+func (a approxEqualArgument) Matches(actual interface{}) bool {
+	epsilon, ok := toFloat64(reflect.ValueOf(a.epsilon))
+	if !ok {
+		return false
+	}
+
+	actualTime, actualIsTime := actual.(time.Time)
+	valueTime, valueIsTime := a.value.(time.Time)
+	if actualIsTime && valueIsTime {
+		return math.Abs(float64(actualTime.Sub(valueTime))) <= epsilon
+	}
+
+	actualFloat, actualOk := toFloat64(reflect.ValueOf(actual))
+	valueFloat, valueOk := toFloat64(reflect.ValueOf(a.value))
+	return actualOk && valueOk && math.Abs(actualFloat-valueFloat) <= epsilon
+}
+
+func (a approxEqualArgument) Describe() string {
+	return fmt.Sprintf("ApproxEqual(%v, %v)", a.value, a.epsilon)
+}
+
+// compare return -1, 0 or 1 if the actual argument is less than, equal to or greater than the value.
+// Return false if they can't be compared.
+func compare(actual interface{}, value interface{}) (int, bool) {
+	actualTime, actualIsTime := actual.(time.Time)
+	valueTime, valueIsTime := value.(time.Time)
+	if actualIsTime || valueIsTime {
+		if !actualIsTime || !valueIsTime {
+			return 0, false
+		}
+		switch {
+		case actualTime.Before(valueTime):
+			return -1, true
+		case actualTime.After(valueTime):
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	actualValue := reflect.ValueOf(actual)
+	valueValue := reflect.ValueOf(value)
+	switch {
+	case isInt(actualValue) && isInt(valueValue):
+		return compareOrdered(actualValue.Int(), valueValue.Int()), true
+	case isUint(actualValue) && isUint(valueValue):
+		return compareOrdered(actualValue.Uint(), valueValue.Uint()), true
+	case isInt(actualValue) && isUint(valueValue):
+		return compareIntUint(actualValue.Int(), valueValue.Uint()), true
+	case isUint(actualValue) && isInt(valueValue):
+		return -compareIntUint(valueValue.Int(), actualValue.Uint()), true
+	}
+
+	actualFloat, actualOk := toFloat64(actualValue)
+	valueFloat, valueOk := toFloat64(valueValue)
+	if !actualOk || !valueOk || math.IsNaN(actualFloat) || math.IsNaN(valueFloat) {
+		return 0, false
+	}
+	return compareOrdered(actualFloat, valueFloat), true
+}
+
+func compareOrdered[T int64 | uint64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIntUint compares exactly a signed and an unsigned integer: a negative int is less than any uint
+func compareIntUint(i int64, u uint64) int {
+	if i < 0 {
+		return -1
+	}
+	return compareOrdered(uint64(i), u)
+}
+
+func isInt(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat64(value reflect.Value) (float64, bool) {
+	switch {
+	case isInt(value):
+		return float64(value.Int()), true
+	case isUint(value):
+		return float64(value.Uint()), true
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,119 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestOrderingMatcher(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Gt", func(t *testing.T) {
+		t.Run("Match numbers of all kinds", func(t *testing.T) {
+			assert.True(t, Gt(18).Matches(19))
+			assert.False(t, Gt(18).Matches(18))
+			assert.True(t, Gt(18).Matches(int8(19)))
+			assert.True(t, Gt(18).Matches(uint(19)))
+			assert.True(t, Gt(18).Matches(18.5))
+			assert.True(t, Gt(uint64(18)).Matches(uint32(19)))
+			assert.False(t, Gt(18).Matches(-1))
+		})
+
+		t.Run("Compare exactly the signed and unsigned integers", func(t *testing.T) {
+			assert.True(t, Lt(uint64(1<<53+1)).Matches(int64(1<<53)))
+			assert.True(t, Gt(int64(1<<53)).Matches(uint64(1<<53+1)))
+			assert.True(t, Gt(-1).Matches(uint64(0)))
+			assert.True(t, Lt(uint(0)).Matches(-1))
+			assert.True(t, Gt(int64(math.MaxInt64)).Matches(uint64(math.MaxUint64)))
+		})
+
+		t.Run("Match time and duration", func(t *testing.T) {
+			assert.True(t, Gt(now).Matches(now.Add(time.Second)))
+			assert.False(t, Gt(now).Matches(now))
+			assert.True(t, Gt(time.Second).Matches(time.Minute))
+		})
+
+		t.Run("Don't match other types", func(t *testing.T) {
+			assert.False(t, Gt(18).Matches("19"))
+			assert.False(t, Gt(18).Matches(nil))
+			assert.False(t, Gt(now).Matches(19))
+			assert.False(t, Gt(18).Matches(now))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "Gt(18)", Gt(18).Describe())
+		})
+	})
+
+	t.Run("Gte", func(t *testing.T) {
+		assert.True(t, Gte(18).Matches(18))
+		assert.True(t, Gte(18).Matches(19.0))
+		assert.False(t, Gte(18).Matches(17))
+		assert.Equal(t, "Gte(18)", Gte(18).Describe())
+	})
+
+	t.Run("Lt", func(t *testing.T) {
+		assert.True(t, Lt(time.Second).Matches(time.Millisecond))
+		assert.False(t, Lt(time.Second).Matches(time.Second))
+		assert.True(t, Lt(now).Matches(now.Add(-time.Second)))
+		assert.Equal(t, "Lt(1s)", Lt(time.Second).Describe())
+	})
+
+	t.Run("Lte", func(t *testing.T) {
+		assert.True(t, Lte(1.5).Matches(1.5))
+		assert.True(t, Lte(1.5).Matches(float32(1.25)))
+		assert.False(t, Lte(1.5).Matches(2))
+		assert.Equal(t, "Lte(1.5)", Lte(1.5).Describe())
+	})
+
+	t.Run("Between", func(t *testing.T) {
+		t.Run("Match inclusive bounds", func(t *testing.T) {
+			assert.True(t, Between(1, 10).Matches(1))
+			assert.True(t, Between(1, 10).Matches(5.5))
+			assert.True(t, Between(1, 10).Matches(uint8(10)))
+			assert.False(t, Between(1, 10).Matches(11))
+			assert.False(t, Between(1, 10).Matches(0))
+		})
+
+		t.Run("Match time", func(t *testing.T) {
+			assert.True(t, Between(now, now.Add(time.Minute)).Matches(now.Add(time.Second)))
+			assert.False(t, Between(now, now.Add(time.Minute)).Matches(now.Add(time.Hour)))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "Between(1, 10)", Between(1, 10).Describe())
+		})
+	})
+
+	t.Run("ApproxEqual", func(t *testing.T) {
+		t.Run("Match numbers", func(t *testing.T) {
+			assert.True(t, ApproxEqual(0.3, 1e-9).Matches(0.1+0.2))
+			assert.True(t, ApproxEqual(10, 1).Matches(int64(11)))
+			assert.False(t, ApproxEqual(10, 1).Matches(12))
+			assert.False(t, ApproxEqual(10, 1).Matches("10"))
+		})
+
+		t.Run("Match time and duration", func(t *testing.T) {
+			assert.True(t, ApproxEqual(now, time.Second).Matches(now.Add(-500*time.Millisecond)))
+			assert.False(t, ApproxEqual(now, time.Second).Matches(now.Add(2*time.Second)))
+			assert.True(t, ApproxEqual(time.Second, time.Millisecond).Matches(time.Second+time.Microsecond))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "ApproxEqual(19.99, 0.001)", ApproxEqual(19.99, 0.001).Describe())
+		})
+	})
+
+	t.Run("Use in AssertCalled", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+
+		mock.MethodWithArguments(20, "price", 0.1+0.2)
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithArguments", Gte(18), Anything, ApproxEqual(0.3, 1e-9)))
+	})
+}
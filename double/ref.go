@@ -0,0 +1,89 @@
+package double
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+)
+
+// Ref is a matcher that binds to the argument of type T of the first call selected by On, or matched by an assertion.
+// Afterwards, it only matches an argument equal to the bound value.
+// At several positions of a call, it matches only equal arguments.
+// It can be used in On, AssertCalled, NumberOfCallsWithArguments... but not nested in another matcher.
+// It correlates the arguments of different calls:
+//
+//	id := NewRef[string]()
+//	Mock.AssertCalled(t, "Create", id)
+//	Mock.AssertCalled(t, "Delete", id)
+type Ref[T any] struct {
+	mutex sync.Mutex
+	value T
+	bound bool
+}
+
+// argumentBinder is implemented by the argument recorders that bind to the first recorded argument, like Ref.
+// Within a call, all the positions of the same binder must match equal arguments.
+type argumentBinder interface {
+	argumentRecorder
+	bindsOnce()
+}
+
+// NewRef creates an unbound Ref of type T.
+func NewRef[T any]() *Ref[T] {
+	return &Ref[T]{}
+}
+
+// Matches an argument of type T, or nil if T is nillable, while the Ref is unbound.
+// Once bound, it matches only an argument equal to the bound value.
+func (r *Ref[T]) Matches(actual interface{}) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.bound {
+		return assert.ObjectsAreEqual(r.value, actual)
+	}
+	_, ok := valueOf[T](actual)
+	return ok
+}
+
+func (r *Ref[T]) record(actual interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.bound {
+		r.value, _ = valueOf[T](actual)
+		r.bound = true
+	}
+}
+
+func (r *Ref[T]) bindsOnce() {}
+
+func (r *Ref[T]) Describe() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.bound {
+		return fmt.Sprintf("Ref[%s](%#v)", r.valueType(), r.value)
+	}
+	return fmt.Sprintf("Ref[%s]", r.valueType())
+}
+
+// Value returns the bound value.
+// Panics if the Ref is unbound.
+func (r *Ref[T]) Value() T {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.bound {
+		panic(fmt.Sprintf("assert: ref: no %s has been bound", r.valueType()))
+	}
+	return r.value
+}
+
+// IsBound returns true if the Ref is bound to a value.
+func (r *Ref[T]) IsBound() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.bound
+}
+
+func (r *Ref[T]) valueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
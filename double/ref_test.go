@@ -0,0 +1,138 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestRef(t *testing.T) {
+	t.Run("Bind the first argument of the type when all the arguments match", func(t *testing.T) {
+		st := &SpiedTestingT{}
+		spy := New[SpyExample](st)
+		ref := NewRef[int]()
+
+		assert.False(t, Arguments{ref}.Matches(st, "1"))
+		assert.True(t, Arguments{ref, "a"}.Matches(st, 1, "a"))
+		assert.False(t, ref.IsBound())
+
+		spy.MethodWithArguments(1, "b", 1.0)
+		spy.MethodWithArguments(2, "a", 1.0)
+		assert.Equal(t, 1, spy.NumberOfCallsWithArguments("MethodWithArguments", ref, "a", Anything))
+		assert.True(t, ref.IsBound())
+		assert.Equal(t, 2, ref.Value())
+	})
+
+	t.Run("Match only the bound value", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := New[SpyExample](tt)
+		ref := NewRef[int]()
+		spy.MethodWithArguments(1, "a", 1.0)
+		spy.NumberOfCallsWithArguments("MethodWithArguments", ref, Anything, Anything)
+
+		assert.True(t, ref.Matches(1))
+		assert.False(t, ref.Matches(2))
+		assert.False(t, ref.Matches(nil))
+	})
+
+	t.Run("Bind nil if the type is nillable", func(t *testing.T) {
+		tt := new(testing.T)
+		spy := New[SpyExample](tt)
+		ref := NewRef[*ExampleType]()
+		spy.MethodWithReferenceArgument(nil)
+
+		assert.Equal(t, 1, spy.NumberOfCallsWithArguments("MethodWithReferenceArgument", ref))
+		assert.False(t, ref.Matches(&ExampleType{}))
+		assert.False(t, NewRef[int]().Matches(nil))
+	})
+
+	t.Run("Panic if unbound", func(t *testing.T) {
+		ref := NewRef[string]()
+
+		assert.PanicsWithValue(t, "assert: ref: no string has been bound", func() {
+			ref.Value()
+		})
+	})
+
+	t.Run("Describe", func(t *testing.T) {
+		ref := NewRef[string]()
+		assert.Equal(t, "Ref[string]", ref.Describe())
+
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		mock.MethodWithArguments(1, "id-1", 1.0)
+		mock.AssertCalled(tt, "MethodWithArguments", Anything, ref, Anything)
+		assert.Equal(t, `Ref[string]("id-1")`, ref.Describe())
+	})
+
+	t.Run("Correlate the arguments of different calls", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		id := NewRef[int]()
+
+		mock.MethodWithArguments(42, "create", 1.0)
+		mock.MethodWithArguments(42, "delete", 1.0)
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithArguments", id, "create", Anything))
+		assert.True(t, mock.AssertCalled(tt, "MethodWithArguments", id, "delete", Anything))
+		assert.Equal(t, 42, id.Value())
+	})
+
+	t.Run("Don't match another value in a later call", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+		id := NewRef[int]()
+
+		mock.MethodWithArguments(43, "delete", 1.0)
+		mock.MethodWithArguments(42, "create", 1.0)
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithArguments", id, "create", Anything))
+		assert.False(t, mock.AssertCalled(tt, "MethodWithArguments", id, "delete", Anything))
+	})
+
+	t.Run("Correlate in On", func(t *testing.T) {
+		tt := new(testing.T)
+		stub := New[StubExample](tt)
+		id := NewRef[int]()
+		stub.On("MethodWithArguments", id, Anything, Anything).Return()
+		stub.On("MethodWithArgumentsAndReturnArguments", id, Anything, Anything).Return(1, nil)
+		stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(0, nil)
+
+		stub.MethodWithArguments(42, "create", 1.0)
+		sameId, _ := stub.MethodWithArgumentsAndReturnArguments(42, "delete", 1.0)
+		otherId, _ := stub.MethodWithArgumentsAndReturnArguments(43, "delete", 1.0)
+
+		assert.Equal(t, 1, sameId)
+		assert.Equal(t, 0, otherId)
+	})
+
+	t.Run("Match only equal arguments at several positions of a call", func(t *testing.T) {
+		tt := new(testing.T)
+		stub := New[StubExample](tt)
+		ref := NewRef[int]()
+		stub.On("Range", ref, ref).Return(1)
+		stub.On("Range", Anything, Anything).Return(0)
+		rangeInformation := MethodInformation{Name: "Range", NumOut: 1}
+
+		different := stub.MethodCalled(rangeInformation, 1, 2)
+		equal := stub.MethodCalled(rangeInformation, 2, 2)
+
+		assert.Equal(t, 0, different.Int(0))
+		assert.Equal(t, 1, equal.Int(0))
+		assert.Equal(t, 2, ref.Value())
+	})
+
+	t.Run("Bind only in the selected call of On", func(t *testing.T) {
+		tt := new(testing.T)
+		stub := New[StubExample](tt)
+		stub.SetState("started")
+		id := NewRef[int]()
+		stub.On("MethodWithArgumentsAndReturnArguments", id, Anything, Anything).InState("done").Return(1, nil)
+		stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(0, nil)
+
+		stub.MethodWithArgumentsAndReturnArguments(42, "create", 1.0)
+
+		assert.False(t, id.IsBound())
+	})
+}
@@ -55,12 +55,14 @@ func GetCallingMethodInformation(caller interface{}) (*MethodInformation, error)
 	if !ok {
 		return nil, fmt.Errorf("couldn't get the caller method information. '%s' is private or does not exist", functionName)
 	}
-	return &MethodInformation{functionName, method.Type.NumOut()}, nil
+	return &MethodInformation{Name: functionName, NumOut: method.Type.NumOut(), IsVariadic: method.Type.IsVariadic()}, nil
 }
 
 type MethodInformation struct {
 	Name   string
 	NumOut int
+	// The last slice of the arguments of a variadic method is matched flattened or not
+	IsVariadic bool
 }
 
 func extractFunctionName(functionPath string) string {
@@ -79,3 +81,63 @@ func extractFunctionName(functionPath string) string {
 
 	return parts[0]
 }
+
+// functionArguments converts the arguments of a call to the values expected by a function of type fnType.
+// The last argument of a variadic function can be passed flattened or as a slice.
+// Return an error if the number of arguments or their types don't match the parameters of the function.
+func functionArguments(fnType reflect.Type, arguments []interface{}) ([]reflect.Value, error) {
+	numIn := fnType.NumIn()
+	if fnType.IsVariadic() && len(arguments) == numIn {
+		arguments = flattenVariadic(fnType.In(numIn-1), arguments)
+	}
+
+	if fnType.IsVariadic() && len(arguments) < numIn-1 || !fnType.IsVariadic() && len(arguments) != numIn {
+		return nil, fmt.Errorf("%s can't be called with %d argument(s)", fnType, len(arguments))
+	}
+
+	values := make([]reflect.Value, len(arguments))
+	for i, argument := range arguments {
+		var expectedType reflect.Type
+		if fnType.IsVariadic() && i >= numIn-1 {
+			expectedType = fnType.In(numIn - 1).Elem()
+		} else {
+			expectedType = fnType.In(i)
+		}
+
+		value, err := argumentValue(argument, expectedType)
+		if err != nil {
+			return nil, fmt.Errorf("%s can't be called: argument %d: %w", fnType, i, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func argumentValue(argument interface{}, expectedType reflect.Type) (reflect.Value, error) {
+	if argument == nil {
+		if !isNilSupported(expectedType) {
+			return reflect.Value{}, fmt.Errorf("<nil> is not assignable to %s", expectedType)
+		}
+		return reflect.Zero(expectedType), nil
+	}
+
+	value := reflect.ValueOf(argument)
+	if !value.Type().AssignableTo(expectedType) {
+		return reflect.Value{}, fmt.Errorf("%s is not assignable to %s", value.Type(), expectedType)
+	}
+	return value, nil
+}
+
+// flattenVariadic expands the last argument when it is the slice of the variadic parameter
+func flattenVariadic(variadicType reflect.Type, arguments []interface{}) []interface{} {
+	last := reflect.ValueOf(arguments[len(arguments)-1])
+	if !last.IsValid() || !last.Type().AssignableTo(variadicType) {
+		return arguments
+	}
+
+	flattened := append([]interface{}{}, arguments[:len(arguments)-1]...)
+	for i := 0; i < last.Len(); i++ {
+		flattened = append(flattened, last.Index(i).Interface())
+	}
+	return flattened
+}
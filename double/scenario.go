@@ -0,0 +1,38 @@
+package double
+
+import "sync"
+
+// ScenarioStarted is the initial state of the scenario of a stub
+const ScenarioStarted = "started"
+
+// scenario holds the state shared by the predefined calls of a stub (see Call.InState and Call.WillSetState)
+type scenario struct {
+	state *string
+	mutex sync.Mutex
+}
+
+func (s *scenario) current() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.state == nil {
+		return ScenarioStarted
+	}
+	return *s.state
+}
+
+func (s *scenario) set(state string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.state = &state
+}
+
+// transition sets the state defined by the call, if any
+func (s *scenario) transition(call *Call) {
+	call.mutex.Lock()
+	nextState := call.nextState
+	call.mutex.Unlock()
+
+	if nextState != nil {
+		s.set(*nextState)
+	}
+}
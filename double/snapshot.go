@@ -0,0 +1,116 @@
+package double
+
+import (
+	"context"
+	"reflect"
+	"unsafe"
+)
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// snapshot deep-copies the arguments (see Spy.SnapshotArguments)
+func snapshot(arguments []interface{}) []interface{} {
+	s := snapshotter{copies: make(map[snapshotKey]reflect.Value)}
+	result := make([]interface{}, len(arguments))
+	for i, argument := range arguments {
+		if argument == nil {
+			continue
+		}
+		result[i] = s.copy(reflect.ValueOf(argument)).Interface()
+	}
+	return result
+}
+
+// snapshotKey identifies a pointed value, so that shared and cyclic pointers are copied once
+type snapshotKey struct {
+	pointer uintptr
+	t       reflect.Type
+}
+
+type snapshotter struct {
+	copies map[snapshotKey]reflect.Value
+}
+
+// copy return a deep copy of the value.
+// The unexported fields of the structs are copied shallowly, except their slices and maps.
+// The channels and the functions are copied shallowly.
+// The errors and the contexts are kept as is, so that their identity can be checked (see ErrorIs).
+func (s snapshotter) copy(value reflect.Value) reflect.Value {
+	if value.Kind() != reflect.Interface && keptAsIs(value.Type()) {
+		return value
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		key := snapshotKey{pointer: value.Pointer(), t: value.Type()}
+		if copied, ok := s.copies[key]; ok {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		s.copies[key] = copied
+		copied.Elem().Set(s.copy(value.Elem()))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(s.copy(value.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			field := copied.Field(i)
+			if field.CanSet() {
+				field.Set(s.copy(field))
+			} else if field.Kind() == reflect.Slice || field.Kind() == reflect.Map {
+				// The code under test reuses the unexported slices, like the bytes of a bytes.Buffer.
+				// They can only be read and set through their address.
+				field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+				field.Set(s.copy(field))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(s.copy(value.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(s.copy(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			copied.SetMapIndex(iterator.Key(), s.copy(iterator.Value()))
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// keptAsIs return if the values of the type are not copied: the errors, the contexts and the pointers to zero-sized values,
+// like the sentinels, have nothing to snapshot but their identity
+func keptAsIs(valueType reflect.Type) bool {
+	return valueType.Implements(errorType) || valueType.Implements(contextType) ||
+		valueType.Kind() == reflect.Pointer && valueType.Elem().Size() == 0
+}
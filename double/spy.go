@@ -1,11 +1,15 @@
 package double
 
+import "sync"
+
 // Spy is a Stub that record actual calls
 // For an example of its usage, refer to the "Example Usage" section at the top
 // of this document.
 type Spy struct {
 	Stub
-	actualCalls ActualCalls
+	actualCalls       ActualCalls
+	actualCallsMutex  sync.Mutex
+	snapshotArguments bool
 }
 
 // Called tells the spy object that a method has been called, and gets an array
@@ -22,14 +26,48 @@ func (s *Spy) Called(arguments ...interface{}) Arguments {
 // appropriate .On .Return() calls)
 // If Call.WaitFor is set, blocks until the channel is closed or receives a message.
 func (s *Spy) MethodCalled(methodInformation MethodInformation, arguments ...interface{}) Arguments {
-	s.actualCalls.append(methodInformation.Name, arguments)
-	return s.Stub.MethodCalled(methodInformation, arguments...)
+	index, recordedArguments := s.recordActualCall(methodInformation.Name, arguments)
+	returnArguments, streams := s.Stub.methodCalled(methodInformation, recordedArguments, arguments...)
+	s.actualCallsMutex.Lock()
+	defer s.actualCallsMutex.Unlock()
+	s.actualCalls[index].Streams = streams
+	return returnArguments
 }
 
 // AddActualCall records the actual call
 func (s *Spy) AddActualCall(arguments ...interface{}) {
 	functionName := GetCallingFunctionName(2)
-	s.actualCalls.append(functionName, arguments)
+	s.recordActualCall(functionName, arguments)
+}
+
+// recordActualCall records the actual call and return its index with the recorded arguments.
+// The calls can be concurrent, for example with Call.AfterLatency.
+func (s *Spy) recordActualCall(methodName string, arguments []interface{}) (int, []interface{}) {
+	recordedArguments := s.recordedArguments(arguments)
+	s.actualCallsMutex.Lock()
+	defer s.actualCallsMutex.Unlock()
+	s.actualCalls.append(methodName, recordedArguments)
+	return len(s.actualCalls) - 1, recordedArguments
+}
+
+// SnapshotArguments changes how the actual calls are recorded. By default, the arguments are recorded as is,
+// so the assertions see the pointed values as they are when asserting. With this option, the arguments
+// are deep-copied when recorded, so the assertions see the values as they were at call time,
+// even if the code under test reuses and mutates them afterwards.
+// The unexported fields of the structs are copied shallowly, except their slices and maps,
+// so that a reused bytes.Buffer or strings.Builder is recorded as it was.
+//
+//	Spy.SnapshotArguments()
+func (s *Spy) SnapshotArguments() {
+	s.snapshotArguments = true
+}
+
+// recordedArguments return the arguments to record in the actual calls (see SnapshotArguments)
+func (s *Spy) recordedArguments(arguments []interface{}) []interface{} {
+	if !s.snapshotArguments {
+		return arguments
+	}
+	return snapshot(arguments)
 }
 
 // NumberOfCalls return the number of calls of the method name passed in parameter
@@ -37,26 +75,51 @@ func (s *Spy) NumberOfCalls(methodName string) int {
 	predicate := func(call ActualCall) bool {
 		return call.MethodName == methodName
 	}
-	return s.actualCalls.count(predicate)
+	return ActualCalls(s.ActualCalls()).count(predicate)
 }
 
-// NumberOfCallsWithArguments return the number of calls of the method with the specified arguments
+// NumberOfCallsWithArguments return the number of calls of the method with the specified arguments.
+// The matchers like Captor and Ref record the arguments of every counted call, each time it is counted.
 func (s *Spy) NumberOfCallsWithArguments(methodName string, arguments ...interface{}) int {
+	return s.numberOfCallsWithArguments(methodName, arguments, true)
+}
+
+// numberOfCallsWithArguments is similar to NumberOfCallsWithArguments, except the counted calls are recorded only if record is set
+func (s *Spy) numberOfCallsWithArguments(methodName string, arguments Arguments, record bool) int {
+	variadic := s.isVariadic(methodName)
 	predicate := func(call ActualCall) bool {
-		return call.matches(s.t, methodName, arguments)
+		if !call.matches(s.t, methodName, variadic, arguments) {
+			return false
+		}
+		if record {
+			arguments.record(variadic, call.Arguments)
+		}
+		return true
+	}
+	return ActualCalls(s.ActualCalls()).count(predicate)
+}
+
+// NumberOfCallsMatching return the number of calls of the method whose whole list of arguments matches the predicate
+func (s *Spy) NumberOfCallsMatching(methodName string, predicate func(arguments Arguments) bool) int {
+	actualCallPredicate := func(call ActualCall) bool {
+		return call.MethodName == methodName && Arguments(call.Arguments).matchesPredicate(s.t, predicate)
 	}
-	return s.actualCalls.count(predicate)
+	return ActualCalls(s.ActualCalls()).count(actualCallPredicate)
 }
 
 // ActualCalls return the actual calls recorded by the Spy
 func (s *Spy) ActualCalls() []ActualCall {
-	return s.actualCalls
+	s.actualCallsMutex.Lock()
+	defer s.actualCallsMutex.Unlock()
+	return append([]ActualCall(nil), s.actualCalls...)
 }
 
 // ActualCall record the information of an actual call
 type ActualCall struct {
 	MethodName string
 	Arguments  []interface{}
+	// Holds how the consumer read the streams returned by the call (see Call.ReturnStream)
+	Streams []*Stream
 }
 
 // NewActualCall constructor
@@ -64,12 +127,12 @@ func NewActualCall(methodName string, arguments ...interface{}) ActualCall {
 	return ActualCall{MethodName: methodName, Arguments: arguments}
 }
 
-func (a ActualCall) matches(t TestingT, methodName string, arguments Arguments) bool {
+func (a ActualCall) matches(t TestingT, methodName string, variadic bool, arguments Arguments) bool {
 	if a.MethodName != methodName {
 		return false
 	}
 
-	return arguments.Matches(t, a.Arguments...)
+	return arguments.matches(t, variadic, a.Arguments)
 }
 
 type ActualCalls []ActualCall
@@ -79,9 +142,9 @@ func (c *ActualCalls) append(methodName string, arguments []interface{}) {
 	*c = append(*c, call)
 }
 
-func (c *ActualCalls) count(predicate func(ActualCall) bool) int {
+func (c ActualCalls) count(predicate func(ActualCall) bool) int {
 	count := 0
-	for _, call := range *c {
+	for _, call := range c {
 		if predicate(call) {
 			count++
 		}
@@ -94,7 +157,9 @@ type ISpy interface {
 	AddActualCall(arguments ...interface{})
 	NumberOfCalls(methodName string) int
 	NumberOfCallsWithArguments(methodName string, arguments ...interface{}) int
+	NumberOfCallsMatching(methodName string, predicate func(arguments Arguments) bool) int
 	ActualCalls() []ActualCall
+	SnapshotArguments()
 }
 
 // Check if Spy implements all methods of ISpy
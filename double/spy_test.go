@@ -1,9 +1,13 @@
 package double_test
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"testing"
+	"time"
 
 	. "github.com/laurentdutheil/go-double/double"
 )
@@ -58,6 +62,171 @@ func TestSpy(t *testing.T) {
 				})
 			})
 
+			t.Run("Streams", func(t *testing.T) {
+				t.Run("Register that the stream is drained", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.On("MethodWithStreamReturnArgument").ReturnStream([]int{1, 2}, 0).Return(nil)
+
+					stream, _ := spy.MethodWithStreamReturnArgument()
+					for range stream {
+					}
+
+					streams := spy.ActualCalls()[0].Streams
+					assert.Len(t, streams, 1)
+					assert.True(t, streams[0].Drained())
+					assert.Equal(t, 2, streams[0].Sent())
+				})
+
+				t.Run("Register that the stream is not drained", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.On("MethodWithStreamReturnArgument").ReturnStream([]int{1, 2}, 0).Return(nil)
+
+					stream, _ := spy.MethodWithStreamReturnArgument()
+					<-stream
+
+					streams := spy.ActualCalls()[0].Streams
+					defer streams[0].Stop()
+					assert.Eventually(t, func() bool { return streams[0].Sent() == 1 }, time.Second, time.Millisecond)
+					assert.False(t, streams[0].Drained())
+				})
+
+				t.Run("Stop the stream that is not drained", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.On("MethodWithStreamReturnArgument").ReturnStream([]int{1, 2, 3}, 0).Return(nil)
+
+					stream, _ := spy.MethodWithStreamReturnArgument()
+					<-stream
+					spy.ActualCalls()[0].Streams[0].Stop()
+
+					select {
+					case <-time.After(time.Second):
+						assert.Fail(t, "The stream is not closed")
+					case _, open := <-stream:
+						// the value being sent when stopped may still be received
+						if open {
+							_, open = <-stream
+						}
+						assert.False(t, open)
+					}
+					assert.False(t, spy.ActualCalls()[0].Streams[0].Drained())
+				})
+
+				t.Run("Stop the stream while it waits the delay", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.On("MethodWithStreamReturnArgument").ReturnStream([]int{1}, time.Hour).KeepStreamOpen().Return(nil)
+
+					stream, _ := spy.MethodWithStreamReturnArgument()
+					spy.ActualCalls()[0].Streams[0].Stop()
+					spy.ActualCalls()[0].Streams[0].Stop()
+
+					select {
+					case <-time.After(time.Second):
+						assert.Fail(t, "The stream is not closed")
+					case _, open := <-stream:
+						assert.False(t, open)
+					}
+				})
+
+				t.Run("Register no stream", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+
+					spy.Method()
+
+					assert.Nil(t, spy.ActualCalls()[0].Streams)
+				})
+			})
+
+			t.Run("SnapshotArguments", func(t *testing.T) {
+				t.Run("Record the live arguments by default", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					aSlice, aMap := []int{1}, map[string]int{"a": 1}
+
+					spy.MethodWithOutArguments(aSlice, aMap)
+					aSlice[0], aMap["a"] = 2, 2
+
+					assert.Equal(t, NewActualCall("MethodWithOutArguments", []int{2}, map[string]int{"a": 2}), spy.ActualCalls()[0])
+				})
+
+				t.Run("Record the arguments as they were at call time", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.SnapshotArguments()
+					aSlice, aMap := []int{1}, map[string]int{"a": 1}
+
+					spy.MethodWithOutArguments(aSlice, aMap)
+					aSlice[0], aMap["a"] = 2, 2
+
+					assert.Equal(t, NewActualCall("MethodWithOutArguments", []int{1}, map[string]int{"a": 1}), spy.ActualCalls()[0])
+					assert.Equal(t, 0, spy.NumberOfCallsWithArguments("MethodWithOutArguments", []int{2}, Anything))
+				})
+
+				t.Run("Record a copy of the pointed values", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.SnapshotArguments()
+					spy.On("MethodWithReferenceArgument", Anything, Anything).Return()
+					type node struct {
+						Value int
+						Next  *node
+					}
+					cycle := &node{Value: 1}
+					cycle.Next = cycle
+
+					spy.MethodCalled(MethodInformation{Name: "MethodWithReferenceArgument"}, cycle, nil)
+					cycle.Value = 2
+
+					recorded := spy.ActualCalls()[0].Arguments[0].(*node)
+					assert.NotSame(t, cycle, recorded)
+					assert.Equal(t, 1, recorded.Value)
+					assert.Same(t, recorded, recorded.Next)
+					assert.Nil(t, spy.ActualCalls()[0].Arguments[1])
+				})
+
+				t.Run("Record a copy of the reused buffers", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.SnapshotArguments()
+					buffer := bytes.NewBufferString("order-42")
+
+					spy.MethodCalled(MethodInformation{Name: "Write"}, buffer)
+					buffer.Reset()
+					buffer.WriteString("XXXXX")
+
+					assert.Equal(t, "order-42", spy.ActualCalls()[0].Arguments[0].(*bytes.Buffer).String())
+				})
+
+				t.Run("Keep the errors and the contexts as is", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.SnapshotArguments()
+					ctx := context.WithValue(context.Background(), requestIdKey{}, "42")
+
+					spy.MethodCalled(MethodInformation{Name: "Report"}, io.EOF, ctx)
+
+					assert.Equal(t, 1, spy.NumberOfCallsWithArguments("Report", ErrorIs(io.EOF), Anything))
+					assert.Same(t, ctx, spy.ActualCalls()[0].Arguments[1])
+				})
+
+				t.Run("Let the stub modify the live arguments", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					spy.SnapshotArguments()
+					spy.On("MethodWithOutArguments", Anything, Anything).SetArg(0, []int{3}).Return()
+					aSlice := []int{1}
+
+					spy.MethodWithOutArguments(aSlice, nil)
+
+					assert.Equal(t, []int{3}, aSlice)
+					assert.Equal(t, []int{1}, spy.ActualCalls()[0].Arguments[0])
+				})
+			})
+
 			t.Run("AddActualCall", func(t *testing.T) {
 				t.Run("Register actual call", func(t *testing.T) {
 					tt := new(testing.T)
@@ -178,6 +347,21 @@ func TestSpy(t *testing.T) {
 					assert.Equal(t, 3, numberOfCalls)
 				})
 			})
+
+			t.Run("NumberOfCallsMatching", func(t *testing.T) {
+				t.Run("Count the calls whose arguments match the predicate", func(t *testing.T) {
+					tt := new(testing.T)
+					spy := test.constructor(tt)
+					lowerThanFloat := func(args Arguments) bool { return float64(args.Int(0)) < args.Get(2).(float64) }
+
+					spy.MethodWithArguments(1, "2", 3.0)
+					spy.MethodWithArguments(4, "2", 3.0)
+					spy.MethodWithArguments(2, "2", 3.0)
+
+					assert.Equal(t, 2, spy.NumberOfCallsMatching("MethodWithArguments", lowerThanFloat))
+					assert.Equal(t, 0, spy.NumberOfCallsMatching("Method", lowerThanFloat))
+				})
+			})
 		})
 	}
 }
@@ -0,0 +1,89 @@
+package double
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Stream records how the consumer read a channel returned by a stubbed method (see Call.ReturnStream).
+type Stream struct {
+	length   int
+	sent     int
+	mutex    sync.Mutex
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newStream(length int) *Stream {
+	return &Stream{length: length, done: make(chan struct{})}
+}
+
+// Stop stops emitting the values, so that the goroutine of the stream ends
+// even if the consumer doesn't read the channel anymore.
+// The channel is closed, even if the call keeps the streams open (see Call.KeepStreamOpen).
+func (s *Stream) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// Sent return the number of values received by the consumer
+func (s *Stream) Sent() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sent
+}
+
+// Drained return true if the consumer received all the values of the stream
+func (s *Stream) Drained() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.sent == s.length
+}
+
+func (s *Stream) valueSent() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sent++
+}
+
+// streamReturn is the placeholder of a stream in the return arguments of a Call
+type streamReturn struct {
+	values reflect.Value
+	delay  func(i int) time.Duration
+}
+
+// start creates a fresh channel and emits the values in another goroutine until the stream is stopped
+func (r *streamReturn) start(keepOpen bool) (interface{}, *Stream) {
+	elementType := r.values.Type().Elem()
+	channel := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, elementType), 0)
+	stream := newStream(r.values.Len())
+
+	go func() {
+		if r.emit(channel, stream) || !keepOpen {
+			channel.Close()
+		}
+	}()
+
+	return channel.Convert(reflect.ChanOf(reflect.RecvDir, elementType)).Interface(), stream
+}
+
+// emit sends the values on the channel after their delays. Return true if the stream has been stopped.
+func (r *streamReturn) emit(channel reflect.Value, stream *Stream) bool {
+	for i := 0; i < r.values.Len(); i++ {
+		select {
+		case <-time.After(r.delay(i)):
+		case <-stream.done:
+			return true
+		}
+
+		chosen, _, _ := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: channel, Send: r.values.Index(i)},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(stream.done)},
+		})
+		if chosen == 1 {
+			return true
+		}
+		stream.valueSent()
+	}
+	return false
+}
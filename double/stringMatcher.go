@@ -0,0 +1,143 @@
+package double
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Regexp matches a text argument that matches the regular expression.
+// A text argument is a string, a []byte or a fmt.Stringer.
+// Panics if the regular expression can't be compiled.
+//
+//	Stub.On("Log", Regexp(`^order \d+ created$`))
+func Regexp(pattern string) Matcher {
+	compiled := regexp.MustCompile(pattern)
+	return textArgument{description: fmt.Sprintf("Regexp(%q)", pattern), matches: compiled.MatchString}
+}
+
+// Contains matches a text argument that contains the substring.
+// A text argument is a string, a []byte or a fmt.Stringer.
+//
+//	Stub.On("Log", Contains("order-42"))
+func Contains(substring string) Matcher {
+	return textArgument{description: fmt.Sprintf("Contains(%q)", substring), matches: func(text string) bool {
+		return strings.Contains(text, substring)
+	}}
+}
+
+// HasPrefix matches a text argument that begins with the prefix.
+// A text argument is a string, a []byte or a fmt.Stringer.
+//
+//	Stub.On("Get", HasPrefix("https://"))
+func HasPrefix(prefix string) Matcher {
+	return textArgument{description: fmt.Sprintf("HasPrefix(%q)", prefix), matches: func(text string) bool {
+		return strings.HasPrefix(text, prefix)
+	}}
+}
+
+// HasSuffix matches a text argument that ends with the suffix.
+// A text argument is a string, a []byte or a fmt.Stringer.
+//
+//	Stub.On("Open", HasSuffix(".json"))
+func HasSuffix(suffix string) Matcher {
+	return textArgument{description: fmt.Sprintf("HasSuffix(%q)", suffix), matches: func(text string) bool {
+		return strings.HasSuffix(text, suffix)
+	}}
+}
+
+// EqualFold matches a text argument equal to the expected text under Unicode case-folding.
+// A text argument is a string, a []byte or a fmt.Stringer.
+//
+//	Stub.On("FindUser", EqualFold("Bob"))
+func EqualFold(expected string) Matcher {
+	return textArgument{description: fmt.Sprintf("EqualFold(%q)", expected), matches: func(text string) bool {
+		return strings.EqualFold(text, expected)
+	}}
+}
+
+// JSONEq matches a text argument that is a JSON document equivalent to the expected one.
+// A text argument is a string, a []byte or a fmt.Stringer.
+// Panics if the expected document can't be parsed.
+//
+//	Stub.On("Post", "/orders", JSONEq(`{"id": 42, "items": []}`))
+func JSONEq(expected string) Matcher {
+	return newStructuredTextArgument("JSONEq", expected, json.Unmarshal)
+}
+
+// YAMLEq matches a text argument that is a YAML document equivalent to the expected one.
+// A text argument is a string, a []byte or a fmt.Stringer.
+// Panics if the expected document can't be parsed.
+//
+//	Stub.On("Apply", YAMLEq("kind: Pod\nmetadata:\n  name: web"))
+func YAMLEq(expected string) Matcher {
+	return newStructuredTextArgument("YAMLEq", expected, yaml.Unmarshal)
+}
+
+type textArgument struct {
+	description string
+	matches     func(text string) bool
+}
+
+func (t textArgument) Matches(actual interface{}) bool {
+	text, ok := textOf(actual)
+	return ok && t.matches(text)
+}
+
+func (t textArgument) Describe() string {
+	return t.description
+}
+
+type structuredTextArgument struct {
+	name             string
+	expected         string
+	expectedDocument interface{}
+	unmarshal        func(data []byte, v interface{}) error
+}
+
+// newStructuredTextArgument parses the expected document once. Panics if it can't be parsed.
+func newStructuredTextArgument(name string, expected string, unmarshal func(data []byte, v interface{}) error) structuredTextArgument {
+	var expectedDocument interface{}
+	if err := unmarshal([]byte(expected), &expectedDocument); err != nil {
+		panic(fmt.Sprintf("assert: arguments: %s: the expected document is invalid: %s", name, err))
+	}
+	return structuredTextArgument{name: name, expected: expected, expectedDocument: expectedDocument, unmarshal: unmarshal}
+}
+
+func (s structuredTextArgument) Matches(actual interface{}) bool {
+	text, ok := textOf(actual)
+	if !ok {
+		return false
+	}
+
+	var actualDocument interface{}
+	if s.unmarshal([]byte(text), &actualDocument) != nil {
+		return false
+	}
+	return assert.ObjectsAreEqual(s.expectedDocument, actualDocument)
+}
+
+func (s structuredTextArgument) Describe() string {
+	return fmt.Sprintf("%s(%q)", s.name, s.expected)
+}
+
+// textOf return the text of a string, a []byte or a fmt.Stringer argument
+func textOf(actual interface{}) (string, bool) {
+	value := reflect.ValueOf(actual)
+	if stringer, ok := actual.(fmt.Stringer); ok && !(value.Kind() == reflect.Ptr && value.IsNil()) {
+		return stringer.String(), true
+	}
+
+	switch {
+	case value.Kind() == reflect.String:
+		return value.String(), true
+	case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+		return string(value.Bytes()), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,114 @@
+package double_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+type orderId int
+
+func (o orderId) String() string {
+	return "order-42"
+}
+
+func TestStringMatcher(t *testing.T) {
+	t.Run("Match string, []byte and fmt.Stringer arguments", func(t *testing.T) {
+		matcher := Contains("order-42")
+
+		assert.True(t, matcher.Matches("created order-42"))
+		assert.True(t, matcher.Matches([]byte("created order-42")))
+		assert.True(t, matcher.Matches(orderId(42)))
+		assert.False(t, matcher.Matches("created order-43"))
+		assert.False(t, matcher.Matches(42))
+		assert.False(t, matcher.Matches(nil))
+	})
+
+	t.Run("Regexp", func(t *testing.T) {
+		t.Run("Match the regular expression", func(t *testing.T) {
+			matcher := Regexp(`^order \d+ created$`)
+
+			assert.True(t, matcher.Matches("order 42 created"))
+			assert.False(t, matcher.Matches("order abc created"))
+		})
+
+		t.Run("Panic when the regular expression can't be compiled", func(t *testing.T) {
+			assert.Panics(t, func() { Regexp("(") })
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `Regexp("^order \\d+$")`, Regexp(`^order \d+$`).Describe())
+		})
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		assert.Equal(t, `Contains("order-42")`, Contains("order-42").Describe())
+	})
+
+	t.Run("HasPrefix", func(t *testing.T) {
+		assert.True(t, HasPrefix("https://").Matches("https://example.com"))
+		assert.False(t, HasPrefix("https://").Matches("http://example.com"))
+		assert.Equal(t, `HasPrefix("https://")`, HasPrefix("https://").Describe())
+	})
+
+	t.Run("HasSuffix", func(t *testing.T) {
+		assert.True(t, HasSuffix(".json").Matches([]byte("config.json")))
+		assert.False(t, HasSuffix(".json").Matches("config.yaml"))
+		assert.Equal(t, `HasSuffix(".json")`, HasSuffix(".json").Describe())
+	})
+
+	t.Run("EqualFold", func(t *testing.T) {
+		assert.True(t, EqualFold("Bob").Matches("bOB"))
+		assert.False(t, EqualFold("Bob").Matches("Bobby"))
+		assert.Equal(t, `EqualFold("Bob")`, EqualFold("Bob").Describe())
+	})
+
+	t.Run("JSONEq", func(t *testing.T) {
+		t.Run("Match equivalent JSON documents", func(t *testing.T) {
+			matcher := JSONEq(`{"id": 42, "items": ["a", "b"]}`)
+
+			assert.True(t, matcher.Matches(`{"items":["a","b"],"id":42}`))
+			assert.True(t, matcher.Matches([]byte(`{"items":["a","b"],"id":42}`)))
+			assert.False(t, matcher.Matches(`{"items":["b","a"],"id":42}`))
+			assert.False(t, matcher.Matches(`not json`))
+		})
+
+		t.Run("Panic if the expected document is invalid", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: arguments: JSONEq: the expected document is invalid: unexpected end of JSON input", func() {
+				JSONEq(`{"id": 42`)
+			})
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `JSONEq("{\"id\": 42}")`, JSONEq(`{"id": 42}`).Describe())
+		})
+	})
+
+	t.Run("YAMLEq", func(t *testing.T) {
+		t.Run("Match equivalent YAML documents", func(t *testing.T) {
+			matcher := YAMLEq("kind: Pod\nmetadata:\n  name: web")
+
+			assert.True(t, matcher.Matches("metadata: {name: web}\nkind: Pod"))
+			assert.False(t, matcher.Matches("kind: Pod\nmetadata:\n  name: db"))
+			assert.False(t, matcher.Matches(": not yaml :"))
+		})
+
+		t.Run("Panic if the expected document is invalid", func(t *testing.T) {
+			assert.Panics(t, func() { YAMLEq("kind: [Pod") })
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, `YAMLEq("kind: Pod")`, YAMLEq("kind: Pod").Describe())
+		})
+	})
+
+	t.Run("Use in AssertCalled", func(t *testing.T) {
+		tt := new(testing.T)
+		mock := New[MockExample](tt)
+
+		mock.MethodWithArguments(1, `{"id": 42}`, 1.0)
+
+		assert.True(t, mock.AssertCalled(tt, "MethodWithArguments", 1, JSONEq(`{ "id" : 42 }`), 1.0))
+	})
+}
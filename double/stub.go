@@ -2,6 +2,7 @@ package double
 
 import (
 	"github.com/stretchr/objx"
+	"reflect"
 )
 
 // Stub provides prepared answers to calls made during test.
@@ -11,7 +12,10 @@ type Stub struct {
 	predefinedCalls Calls
 	t               TestingT
 	caller          interface{}
+	delegate        interface{}
 	testData        objx.Map
+	mostSpecific    bool
+	scenario        scenario
 }
 
 // On starts a description of an expectation of the specified method
@@ -23,6 +27,16 @@ func (s *Stub) On(methodName string, arguments ...interface{}) *Call {
 	return call
 }
 
+// OnMatch starts a description of an execution of the method whose whole list of arguments matches the predicate.
+// It expresses the constraints that span several arguments.
+//
+//	Stub.OnMatch("Range", func(args Arguments) bool { return args.Int(0) < args.Int(1) }).Return(items)
+func (s *Stub) OnMatch(methodName string, predicate func(arguments Arguments) bool) *Call {
+	call := s.predefinedCalls.append(methodName, nil)
+	call.predicate = predicate
+	return call
+}
+
 // Called tells the stub object that a method has been called, and gets an array
 // of arguments to return.  Fail the test if the call is unexpected (i.e. not preceded by
 // appropriate .On .Return() calls)
@@ -35,18 +49,39 @@ func (s *Stub) Called(arguments ...interface{}) Arguments {
 // MethodCalled tells the stub object that a method has been called, and gets an array
 // of arguments to return.  Fail the test if the call is unexpected (i.e. not preceded by
 // appropriate .On .Return() calls)
+// If a real implementation is set (see Wrap), the unexpected call is forwarded to it.
 // If Call.WaitFor is set, blocks until the channel is closed or receives a message.
 func (s *Stub) MethodCalled(methodInformation MethodInformation, arguments ...interface{}) Arguments {
+	returnArguments, _ := s.methodCalled(methodInformation, arguments, arguments...)
+	return returnArguments
+}
+
+// methodCalled is similar to MethodCalled, except it also returns the streams started by the call.
+// The matchers like Captor and Ref record the recordedArguments, that can be snapshotted (see Spy.SnapshotArguments).
+func (s *Stub) methodCalled(methodInformation MethodInformation, recordedArguments []interface{}, arguments ...interface{}) (Arguments, []*Stream) {
 	s.checkInitialization()
 
-	foundCall := s.predefinedCalls.find(s.t, methodInformation.Name, arguments...)
+	var foundCall *Call
+	state := s.State()
+	if s.mostSpecific {
+		foundCall = s.predefinedCalls.findMostSpecific(s.t, state, methodInformation, arguments...)
+	} else {
+		foundCall = s.predefinedCalls.find(s.t, state, methodInformation, arguments...)
+	}
+	s.scenario.transition(foundCall)
+
+	if foundCall == noCallFound && s.delegate != nil {
+		return s.delegateCall(methodInformation.Name, arguments), nil
+	}
 
 	if foundCall == noCallFound && methodInformation.NumOut > 0 {
 		s.t.Errorf("I don't know what to return because the method call was unexpected.\n\tDo Stub.On(\"%s\").Return(...) first", methodInformation.Name)
 		s.t.FailNow()
 	}
 
-	return foundCall.called(arguments...)
+	// The matchers like Captor and Ref record only the arguments of the selected call
+	foundCall.Arguments.record(methodInformation.IsVariadic, recordedArguments)
+	return foundCall.called(s.t, arguments...)
 }
 
 // Test sets the test struct variable of the stub object.
@@ -61,6 +96,12 @@ func (s *Stub) Caller(caller interface{}) {
 	s.caller = caller
 }
 
+// Delegate sets the real implementation that receives the calls without predefined answer.
+// If you don't use the double.Wrap constructor, you have to set it yourself.
+func (s *Stub) Delegate(real interface{}) {
+	s.delegate = real
+}
+
 // PredefinedCalls return the predefined calls of the Stub
 func (s *Stub) PredefinedCalls() []*Call {
 	return s.predefinedCalls
@@ -95,12 +136,91 @@ func (s *Stub) When(method interface{}, arguments ...interface{}) *Call {
 	return call
 }
 
+// MatchMostSpecific changes how a method call is matched with the predefined calls.
+// By default, the first declared call that matches is used. With this option, the call
+// with the most specific arguments is used: exact values beat matchers (AnythingOfType, MatchedBy...),
+// which beat Anything. Ties are broken by the last declared call, so a test can override
+// the calls predefined by a shared setup.
+//
+//	Stub.MatchMostSpecific()
+//	Stub.On("Method", Anything).Return(0)
+//	Stub.On("Method", 1).Return(1)
+func (s *Stub) MatchMostSpecific() {
+	s.mostSpecific = true
+}
+
+// State return the current state of the scenario of the stub (see Call.InState).
+func (s *Stub) State() string {
+	return s.scenario.current()
+}
+
+// SetState sets the current state of the scenario of the stub.
+//
+//	Stub.SetState("started")
+func (s *Stub) SetState(state string) {
+	s.scenario.set(state)
+}
+
+// Default predefines the answer of a method whatever its arguments.
+// The method is either the method name or the method itself.
+// The default answer is used only when no other predefined call matches, regardless of the declaration order.
+//
+//	Stub.Default("Method").Return(0, nil)
+//	Stub.Default(Stub.Method).Return(0, nil)
+//	Stub.On("Method", 1).Return(1, nil)
+func (s *Stub) Default(method interface{}) *Call {
+	methodName, ok := method.(string)
+	if !ok {
+		s.checkInitialization()
+
+		functionName, err := GetFunctionName(method)
+		if err != nil {
+			s.t.Errorf("Please pass the method name or the function as an argument : stub.Default(stub.Method)")
+			s.t.FailNow()
+		}
+		methodName = functionName
+	}
+
+	call := s.predefinedCalls.append(methodName, nil)
+	call.isDefault = true
+	return call
+}
+
 func (s *Stub) checkInitialization() {
 	if s.t == nil || s.caller == nil {
 		panic("Please use double.New constructor to initialize correctly.")
 	}
 }
 
+func (s *Stub) delegateCall(methodName string, arguments Arguments) Arguments {
+	method := reflect.ValueOf(s.delegate).MethodByName(methodName)
+	if !method.IsValid() {
+		s.t.Errorf("couldn't forward the call to the real implementation. '%s' is private or does not exist in %T", methodName, s.delegate)
+		s.t.FailNow()
+	}
+
+	in, err := functionArguments(method.Type(), arguments)
+	if err != nil {
+		s.t.Errorf("couldn't forward the call to the real implementation. %s", err)
+		s.t.FailNow()
+	}
+
+	var result Arguments
+	for _, value := range method.Call(in) {
+		result = append(result, value.Interface())
+	}
+	return result
+}
+
+// isVariadic return if the method of the caller is variadic. A private or unknown method is not.
+func (s *Stub) isVariadic(methodName string) bool {
+	if s.caller == nil {
+		return false
+	}
+	method, ok := reflect.TypeOf(s.caller).MethodByName(methodName)
+	return ok && method.Type.IsVariadic()
+}
+
 func (s *Stub) getMethodInformation() *MethodInformation {
 	s.checkInitialization()
 
@@ -114,11 +234,16 @@ func (s *Stub) getMethodInformation() *MethodInformation {
 
 type IStub interface {
 	On(methodName string, arguments ...interface{}) *Call
+	OnMatch(methodName string, predicate func(arguments Arguments) bool) *Call
 	Called(arguments ...interface{}) Arguments
 	MethodCalled(methodInformation MethodInformation, arguments ...interface{}) Arguments
 	PredefinedCalls() []*Call
 	TestData() objx.Map
 	When(method interface{}, arguments ...interface{}) *Call
+	Default(method interface{}) *Call
+	MatchMostSpecific()
+	State() string
+	SetState(state string)
 }
 
 // Check if Stub implements all methods of IStub
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	. "github.com/laurentdutheil/go-double/double"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
 	"time"
 )
@@ -46,6 +47,53 @@ func TestStub(t *testing.T) {
 				})
 			})
 
+			t.Run("Variadic arguments", func(t *testing.T) {
+				t.Run("Match the variadic arguments flattened or not", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithVariadicArguments", "flattened", 1, 2).Return(1)
+					stub.On("MethodWithVariadicArguments", "unflattened", []int{1, 2}).Return(2)
+					stub.On("MethodWithVariadicArguments", "rest", Rest(Gt(0))).Return(3)
+
+					assert.Equal(t, 1, stub.MethodWithVariadicArguments("flattened", 1, 2))
+					assert.Equal(t, 2, stub.MethodWithVariadicArguments("unflattened", 1, 2))
+					assert.Equal(t, 3, stub.MethodWithVariadicArguments("rest", 1, 2, 3))
+				})
+
+				t.Run("Don't flatten the slice argument of a method that is not variadic", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithSliceArgument", 1, 2).Return(1)
+
+					st.AssertFailNowWasCalled(t, func() { stub.MethodWithSliceArgument([]int{1, 2}) })
+				})
+			})
+
+			t.Run("OnMatch", func(t *testing.T) {
+				t.Run("Match the whole list of arguments with the predicate", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.OnMatch("MethodWithArgumentsAndReturnArguments", func(args Arguments) bool {
+						return float64(args.Int(0)) < args.Get(2).(float64)
+					}).Return(1, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(0, nil)
+
+					lower, _ := stub.MethodWithArgumentsAndReturnArguments(1, "", 2.0)
+					higher, _ := stub.MethodWithArgumentsAndReturnArguments(3, "", 2.0)
+
+					assert.Equal(t, 1, lower)
+					assert.Equal(t, 0, higher)
+				})
+
+				t.Run("Don't match another method", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.OnMatch("MethodWithArgumentsAndReturnArguments", func(args Arguments) bool { return true }).Return(1, nil)
+
+					assert.Panics(t, func() { _, _ = stub.MethodWithReturnArguments() })
+				})
+			})
+
 			t.Run("Called", func(t *testing.T) {
 				t.Run("Panic if don't use the New constructor method", func(t *testing.T) {
 					stub := StubExample{}
@@ -294,6 +342,55 @@ func TestStub(t *testing.T) {
 				})
 			})
 
+			t.Run("On AfterLatency", func(t *testing.T) {
+				t.Run("Wait a duration drawn from the latency", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("Method").AfterLatency(UniformJitter(15*time.Millisecond, 5*time.Millisecond), 42)
+
+					done := make(chan string)
+					go func() {
+						stub.Method()
+						done <- "done"
+					}()
+
+					// check that it is not done before
+					select {
+					case <-time.After(5 * time.Millisecond):
+						// Pass
+					case <-done:
+						assert.Fail(t, "Have to wait until the duration")
+					}
+
+					// check that it is done after
+					select {
+					case <-time.After(30 * time.Millisecond):
+						assert.Fail(t, "The wait is too long")
+					case msg := <-done:
+						assert.Equal(t, "done", msg)
+					}
+				})
+
+				t.Run("Wait concurrently for the concurrent calls", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("Method").AfterLatency(UniformJitter(50*time.Millisecond, 0), 42)
+
+					start := time.Now()
+					var wg sync.WaitGroup
+					for i := 0; i < 4; i++ {
+						wg.Add(1)
+						go func() {
+							defer wg.Done()
+							stub.Method()
+						}()
+					}
+					wg.Wait()
+
+					assert.Less(t, time.Since(start), 150*time.Millisecond)
+				})
+			})
+
 			t.Run("On Run", func(t *testing.T) {
 				t.Run("Run function on a called method without argument", func(t *testing.T) {
 					tt := new(testing.T)
@@ -323,6 +420,596 @@ func TestStub(t *testing.T) {
 
 					assert.True(t, ref.ran)
 				})
+
+				t.Run("Run function with the parameters of the method", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					fn := func(aInt int, aString string, aFloat float64) {
+						assert.Equal(t, 1, aInt)
+						assert.Equal(t, "2", aString)
+						assert.Equal(t, 3.0, aFloat)
+					}
+					stub.On("MethodWithArguments", 1, "2", 3.0).Run(fn)
+
+					stub.MethodWithArguments(1, "2", 3.0)
+				})
+
+				t.Run("Run function with a reference parameter", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithReferenceArgument", Anything).Run(func(ref *ExampleType) {
+						ref.ran = true
+					})
+
+					ref := &ExampleType{}
+					stub.MethodWithReferenceArgument(ref)
+
+					assert.True(t, ref.ran)
+				})
+
+				t.Run("FailNow when the parameters of the function don't match the arguments", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithArguments", 1, "2", 3.0).Run(func(aInt int, aString int, aFloat float64) {})
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithArguments(1, "2", 3.0)
+					})
+					assert.Equal(t, "Run: func(int, int, float64) can't be called: argument 1: string is not assignable to int", st.errorMessages[0])
+				})
+
+				t.Run("FailNow when the number of parameters of the function doesn't match the arguments", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithArguments", 1, "2", 3.0).Run(func(aInt int) {})
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithArguments(1, "2", 3.0)
+					})
+					assert.Equal(t, "Run: func(int) can't be called with 3 argument(s)", st.errorMessages[0])
+				})
+
+				t.Run("Ignore a nil handler", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					var handler func(Arguments)
+					stub.On("Method").Run(handler)
+					stub.On("MethodWithArguments", Anything, Anything, Anything).Run(nil)
+
+					assert.NotPanics(t, func() {
+						stub.Method()
+						stub.MethodWithArguments(1, "2", 3.0)
+					})
+				})
+
+				t.Run("Panic when the handler is not a function", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+
+					assert.PanicsWithValue(t, "assert: run: 123 is not a func", func() {
+						stub.On("Method").Run(123)
+					})
+				})
+
+				t.Run("Panic when the handler returns values", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+
+					assert.PanicsWithValue(t, "assert: run: func(*double_test.ExampleType) error must not return values", func() {
+						stub.On("MethodWithReferenceArgument", Anything).Run(func(ref *ExampleType) error { return nil })
+					})
+				})
+			})
+
+			t.Run("On SetArg", func(t *testing.T) {
+				t.Run("Set the value of a pointer argument", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithReferenceArgument", Anything).SetArg(0, ExampleType{ran: true})
+
+					ref := &ExampleType{}
+					stub.MethodWithReferenceArgument(ref)
+
+					assert.True(t, ref.ran)
+				})
+
+				t.Run("Set the value pointed by the value of a pointer argument", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithReferenceArgument", Anything).SetArg(0, &ExampleType{ran: true})
+
+					ref := &ExampleType{}
+					stub.MethodWithReferenceArgument(ref)
+
+					assert.True(t, ref.ran)
+				})
+
+				t.Run("Copy the elements of a slice argument and the entries of a map argument", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithOutArguments", Anything, Anything).
+						SetArg(0, []int{1, 2}).
+						SetArg(1, map[string]int{"foo": 1})
+
+					aSlice := make([]int, 3)
+					aMap := map[string]int{"bar": 2}
+					stub.MethodWithOutArguments(aSlice, aMap)
+
+					assert.Equal(t, []int{1, 2, 0}, aSlice)
+					assert.Equal(t, map[string]int{"foo": 1, "bar": 2}, aMap)
+				})
+
+				t.Run("FailNow when the types are incompatible", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithOutArguments", Anything, Anything).SetArg(0, []string{"foo"})
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithOutArguments(make([]int, 1), nil)
+					})
+					assert.Equal(t, "SetArg(0): []string is not assignable to []int", st.errorMessages[0])
+				})
+
+				t.Run("FailNow when the argument is not a pointer, a slice or a map", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithArguments", 1, "2", 3.0).SetArg(0, 2)
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithArguments(1, "2", 3.0)
+					})
+					assert.Equal(t, "SetArg(0): int is not a pointer, a slice or a map", st.errorMessages[0])
+				})
+
+				t.Run("FailNow when the index is out of range", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithReferenceArgument", Anything).SetArg(1, ExampleType{})
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithReferenceArgument(&ExampleType{})
+					})
+					assert.Equal(t, "SetArg(1): there are 1 argument(s)", st.errorMessages[0])
+				})
+			})
+
+			t.Run("On CallArg", func(t *testing.T) {
+				t.Run("Call the function argument with the arguments", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithCallbackArgument", Anything).CallArg(0, 1, "2").CallArg(0, 3, "4")
+
+					var calls []string
+					stub.MethodWithCallbackArgument(func(aInt int, aString string) {
+						calls = append(calls, fmt.Sprintf("%d %s", aInt, aString))
+					})
+
+					assert.Equal(t, []string{"1 2", "3 4"}, calls)
+				})
+
+				t.Run("FailNow when the argument is not a function", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithArguments", 1, "2", 3.0).CallArg(0)
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithArguments(1, "2", 3.0)
+					})
+					assert.Equal(t, "CallArg(0): int is not a func", st.errorMessages[0])
+				})
+
+				t.Run("FailNow when the function argument is nil", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithCallbackArgument", Anything).CallArg(0, 1, "2")
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithCallbackArgument(nil)
+					})
+					assert.Equal(t, "CallArg(0): the argument is a nil func(int, string)", st.errorMessages[0])
+				})
+
+				t.Run("FailNow when the function can't be called with the arguments", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithCallbackArgument", Anything).CallArg(0, "1", "2")
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithCallbackArgument(func(aInt int, aString string) {})
+					})
+					assert.Equal(t, "CallArg(0): func(int, string) can't be called: argument 0: string is not assignable to int", st.errorMessages[0])
+				})
+				t.Run("Let the function call the method again", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithCallbackArgument", Anything).CallArg(0, 1, "2")
+
+					depth := 0
+					var walk func(aInt int, aString string)
+					walk = func(aInt int, aString string) {
+						depth++
+						if depth < 3 {
+							stub.MethodWithCallbackArgument(walk)
+						}
+					}
+					done := make(chan struct{})
+					go func() {
+						stub.MethodWithCallbackArgument(walk)
+						close(done)
+					}()
+
+					select {
+					case <-time.After(time.Second):
+						assert.Fail(t, "The recursive call is blocked")
+					case <-done:
+						assert.Equal(t, 3, depth)
+					}
+				})
+			})
+
+			t.Run("On CallArgAsync", func(t *testing.T) {
+				t.Run("Call the function argument after the delay", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithCallbackArgument", Anything).CallArgAsync(0, 10*time.Millisecond, 1, "2")
+
+					done := make(chan string)
+					stub.MethodWithCallbackArgument(func(aInt int, aString string) {
+						done <- fmt.Sprintf("%d %s", aInt, aString)
+					})
+
+					// check that it is not called before
+					select {
+					case <-time.After(5 * time.Millisecond):
+						// Pass
+					case <-done:
+						assert.Fail(t, "Have to wait until the delay")
+					}
+
+					// check that it is called after
+					select {
+					case <-time.After(20 * time.Millisecond):
+						assert.Fail(t, "The wait is too long")
+					case msg := <-done:
+						assert.Equal(t, "1 2", msg)
+					}
+				})
+
+				t.Run("Wait for the function", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					call := stub.On("MethodWithCallbackArgument", Anything).CallArgAsync(0, 10*time.Millisecond, 1, "2")
+
+					called := false
+					stub.MethodWithCallbackArgument(func(aInt int, aString string) { called = true })
+					call.WaitAsync()
+
+					assert.True(t, called)
+				})
+
+				t.Run("FailNow when the function can't be called with the arguments", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithCallbackArgument", Anything).CallArgAsync(0, 0, 1)
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.MethodWithCallbackArgument(func(aInt int, aString string) {})
+					})
+					assert.Equal(t, "CallArgAsync(0): func(int, string) can't be called with 1 argument(s)", st.errorMessages[0])
+				})
+
+				t.Run("Report the panic of the function", func(t *testing.T) {
+					st := NewAsyncSpiedTestingT()
+					stub := test.constructor(st)
+					stub.On("MethodWithCallbackArgument", Anything).CallArgAsync(0, 0, 1, "2")
+
+					stub.MethodWithCallbackArgument(func(aInt int, aString string) { panic("callback failed") })
+
+					select {
+					case <-time.After(time.Second):
+						assert.Fail(t, "The panic is not reported")
+					case msg := <-st.errors:
+						assert.Equal(t, "CallArgAsync(0): the function panicked: callback failed", msg)
+					}
+				})
+			})
+
+			t.Run("Default", func(t *testing.T) {
+				t.Run("Predefine default answer with the method name", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.Default("MethodWithArgumentsAndReturnArguments").Return(1, nil)
+
+					aInt, err := stub.MethodWithArgumentsAndReturnArguments(123, "123", 123.0)
+
+					assert.Equal(t, 1, aInt)
+					assert.Nil(t, err)
+				})
+
+				t.Run("Predefine default answer with the method", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+
+					call := stub.Default(stub.MethodWithArgumentsAndReturnArguments).Return(1, nil)
+
+					assert.Equal(t, "MethodWithArgumentsAndReturnArguments", call.MethodName)
+					assert.Contains(t, stub.PredefinedCalls(), call)
+				})
+
+				t.Run("Prefer predefined call even if declared after", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.Default("MethodWithArgumentsAndReturnArguments").Return(1, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", 2, "2", 2.0).Return(2, nil)
+
+					aInt, _ := stub.MethodWithArgumentsAndReturnArguments(2, "2", 2.0)
+					assert.Equal(t, 2, aInt)
+
+					aInt, _ = stub.MethodWithArgumentsAndReturnArguments(3, "3", 3.0)
+					assert.Equal(t, 1, aInt)
+				})
+
+				t.Run("Use default answer when predefined call can't be called anymore", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.Default("MethodWithReturnArguments").Return(1, nil)
+					stub.On("MethodWithReturnArguments").Return(2, nil).Once()
+
+					aInt, _ := stub.MethodWithReturnArguments()
+					assert.Equal(t, 2, aInt)
+
+					aInt, _ = stub.MethodWithReturnArguments()
+					assert.Equal(t, 1, aInt)
+				})
+
+				t.Run("FailNow if pass anything other than a method name or a function", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+
+					st.AssertFailNowWasCalled(t, func() {
+						stub.Default(123)
+					})
+					expectedMessage := "Please pass the method name or the function as an argument : stub.Default(stub.Method)"
+					assert.Equal(t, expectedMessage, st.errorMessages[0])
+				})
+			})
+
+			t.Run("MatchMostSpecific", func(t *testing.T) {
+				t.Run("Exact values beat matchers that beat Anything", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.MatchMostSpecific()
+					stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(1, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", AnythingOfType("int"), Anything, Anything).Return(2, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", 3, Anything, Anything).Return(3, nil)
+
+					aInt, _ := stub.MethodWithArgumentsAndReturnArguments(3, "3", 3.0)
+					assert.Equal(t, 3, aInt)
+
+					aInt, _ = stub.MethodWithArgumentsAndReturnArguments(4, "4", 4.0)
+					assert.Equal(t, 2, aInt)
+				})
+
+				t.Run("Ties are broken by the last declared call", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.MatchMostSpecific()
+					stub.On("MethodWithArgumentsAndReturnArguments", 1, "1", Anything).Return(1, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", 1, Anything, 1.0).Return(2, nil)
+
+					aInt, _ := stub.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+					assert.Equal(t, 2, aInt)
+				})
+
+				t.Run("Predicates beat Anything but not exact values", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.MatchMostSpecific()
+					stub.On("MethodWithArgumentsAndReturnArguments", 3, "3", 3.0).Return(3, nil)
+					stub.OnMatch("MethodWithArgumentsAndReturnArguments", func(args Arguments) bool { return args.Int(0) > 0 }).Return(1, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(0, nil)
+
+					aInt, _ := stub.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+					assert.Equal(t, 1, aInt)
+
+					aInt, _ = stub.MethodWithArgumentsAndReturnArguments(3, "3", 3.0)
+					assert.Equal(t, 3, aInt)
+				})
+
+				t.Run("Skip the calls that can't be called anymore", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.MatchMostSpecific()
+					stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(1, nil)
+					stub.On("MethodWithArgumentsAndReturnArguments", 1, "1", 1.0).Return(2, nil).Once()
+
+					aInt, _ := stub.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+					assert.Equal(t, 2, aInt)
+
+					aInt, _ = stub.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+					assert.Equal(t, 1, aInt)
+				})
+
+				t.Run("Use default answer only when no call matches", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.MatchMostSpecific()
+					stub.On("MethodWithArgumentsAndReturnArguments", Anything, Anything, Anything).Return(1, nil)
+					stub.Default("MethodWithArgumentsAndReturnArguments").Return(2, nil)
+
+					aInt, _ := stub.MethodWithArgumentsAndReturnArguments(1, "1", 1.0)
+					assert.Equal(t, 1, aInt)
+				})
+			})
+
+			t.Run("Scenario", func(t *testing.T) {
+				t.Run("Start in the started state", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+
+					assert.Equal(t, ScenarioStarted, stub.State())
+				})
+
+				t.Run("Use the calls of the current state and change the state", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithReturnArguments").InState(ScenarioStarted).Return(1, nil).WillSetState("running")
+					stub.On("MethodWithReturnArguments").InState("running").Return(2, nil).WillSetState("done")
+					stub.On("MethodWithReturnArguments").InState("done").Return(3, nil)
+
+					for _, expectedInt := range []int{1, 2, 3, 3} {
+						aInt, _ := stub.MethodWithReturnArguments()
+						assert.Equal(t, expectedInt, aInt)
+					}
+					assert.Equal(t, "done", stub.State())
+				})
+
+				t.Run("Change the state from another method", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("Method").WillSetState("done")
+					stub.On("MethodWithReturnArguments").InState(ScenarioStarted).Return(1, nil)
+					stub.On("MethodWithReturnArguments").InState("done").Return(2, nil)
+
+					aInt, _ := stub.MethodWithReturnArguments()
+					assert.Equal(t, 1, aInt)
+
+					stub.Method()
+
+					aInt, _ = stub.MethodWithReturnArguments()
+					assert.Equal(t, 2, aInt)
+				})
+
+				t.Run("Set the state", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithReturnArguments").InState("done").Return(2, nil)
+					stub.SetState("done")
+
+					aInt, _ := stub.MethodWithReturnArguments()
+
+					assert.Equal(t, 2, aInt)
+				})
+
+				t.Run("FailNow when no call is predefined in the current state", func(t *testing.T) {
+					st := &SpiedTestingT{}
+					stub := test.constructor(st)
+					stub.On("MethodWithReturnArguments").InState("done").Return(2, nil)
+
+					st.AssertFailNowWasCalled(t, func() {
+						_, _ = stub.MethodWithReturnArguments()
+					})
+				})
+			})
+
+			t.Run("On ReturnStream", func(t *testing.T) {
+				t.Run("Return a channel that emits the values and closes", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithStreamReturnArgument").ReturnStream([]int{1, 2, 3}, 0).Return(nil)
+
+					stream, err := stub.MethodWithStreamReturnArgument()
+
+					assert.Nil(t, err)
+					var values []int
+					for value := range stream {
+						values = append(values, value)
+					}
+					assert.Equal(t, []int{1, 2, 3}, values)
+				})
+
+				t.Run("Return a fresh channel on each call", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithStreamReturnArgument").ReturnStream([]int{1}, 0).Return(nil)
+
+					for i := 0; i < 2; i++ {
+						stream, _ := stub.MethodWithStreamReturnArgument()
+						assert.Equal(t, 1, <-stream)
+					}
+				})
+
+				t.Run("Wait the interval before each value", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithStreamReturnArgument").ReturnStream([]int{1}, 10*time.Millisecond).Return(nil)
+
+					stream, _ := stub.MethodWithStreamReturnArgument()
+
+					// check that it is not emitted before
+					select {
+					case <-time.After(5 * time.Millisecond):
+						// Pass
+					case <-stream:
+						assert.Fail(t, "Have to wait the interval")
+					}
+
+					// check that it is emitted after
+					select {
+					case <-time.After(20 * time.Millisecond):
+						assert.Fail(t, "The wait is too long")
+					case value := <-stream:
+						assert.Equal(t, 1, value)
+					}
+				})
+
+				t.Run("Wait the delay of each value", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					delays := []time.Duration{0, 20 * time.Millisecond}
+					stub.On("MethodWithStreamReturnArgument").ReturnStreamWithDelays([]int{1, 2}, func(i int) time.Duration { return delays[i] }).Return(nil)
+
+					stream, _ := stub.MethodWithStreamReturnArgument()
+					start := time.Now()
+					assert.Equal(t, 1, <-stream)
+					assert.Less(t, time.Since(start), 10*time.Millisecond)
+
+					assert.Equal(t, 2, <-stream)
+					assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+				})
+
+				t.Run("Keep the channel open after the last value", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					stub.On("MethodWithStreamReturnArgument").ReturnStream([]int{1}, 0).KeepStreamOpen().Return(nil)
+
+					stream, _ := stub.MethodWithStreamReturnArgument()
+					assert.Equal(t, 1, <-stream)
+
+					select {
+					case <-time.After(10 * time.Millisecond):
+						// Pass
+					case <-stream:
+						assert.Fail(t, "The stream has to stay open")
+					}
+				})
+
+				t.Run("Stop the streams started by the calls", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+					call := stub.On("MethodWithStreamReturnArgument").ReturnStream([]int{1, 2}, 0).Return(nil)
+
+					first, _ := stub.MethodWithStreamReturnArgument()
+					second, _ := stub.MethodWithStreamReturnArgument()
+					assert.Equal(t, 1, <-first)
+					call.StopStreams()
+
+					assert.Len(t, call.Streams(), 2)
+					for range first {
+					}
+					for range second {
+					}
+					assert.False(t, call.Streams()[1].Drained())
+				})
+
+				t.Run("Panic when values are not a slice", func(t *testing.T) {
+					tt := new(testing.T)
+					stub := test.constructor(tt)
+
+					assert.PanicsWithValue(t, "assert: stream: 1 is not a slice", func() {
+						stub.On("MethodWithStreamReturnArgument").ReturnStream(1, 0)
+					})
+				})
 			})
 
 			t.Run("TestData", func(t *testing.T) {
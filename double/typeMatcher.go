@@ -0,0 +1,89 @@
+package double
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsNil matches a nil argument, or a nil pointer, map, slice, channel, function or interface.
+//
+//	Mock.AssertCalled(t, "Save", IsNil())
+func IsNil() Matcher {
+	return nilArgument{}
+}
+
+// NotNil matches an argument that IsNil doesn't match.
+//
+//	Mock.AssertCalled(t, "Save", NotNil())
+func NotNil() Matcher {
+	return notNilArgument{}
+}
+
+// Implements matches an argument whose type implements the interface I.
+// Panics if I is not an interface.
+//
+//	Stub.On("Write", Implements[io.Reader]())
+func Implements[I any]() Matcher {
+	interfaceType := reflect.TypeOf((*I)(nil)).Elem()
+	if interfaceType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("assert: arguments: %s is not an interface", interfaceType))
+	}
+	return typeArgument{description: fmt.Sprintf("Implements[%s]()", interfaceType), accept: func(actualType reflect.Type) bool {
+		return actualType != nil && actualType.Implements(interfaceType)
+	}}
+}
+
+// AssignableTo matches an argument assignable to the type T.
+// A nil argument matches if T is a pointer, a map, a slice, a channel, a function or an interface.
+//
+//	Stub.On("Save", AssignableTo[fmt.Stringer]())
+func AssignableTo[T any]() Matcher {
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
+	return typeArgument{description: fmt.Sprintf("AssignableTo[%s]()", targetType), accept: func(actualType reflect.Type) bool {
+		if actualType == nil {
+			return isNilSupported(targetType)
+		}
+		return actualType.AssignableTo(targetType)
+	}}
+}
+
+type nilArgument struct{}
+
+func (nilArgument) Matches(actual interface{}) bool {
+	return isNil(actual)
+}
+
+func (nilArgument) Describe() string {
+	return "IsNil()"
+}
+
+type notNilArgument struct{}
+
+func (notNilArgument) Matches(actual interface{}) bool {
+	return !isNil(actual)
+}
+
+func (notNilArgument) Describe() string {
+	return "NotNil()"
+}
+
+type typeArgument struct {
+	description string
+	accept      func(actualType reflect.Type) bool
+}
+
+func (t typeArgument) Matches(actual interface{}) bool {
+	return t.accept(reflect.TypeOf(actual))
+}
+
+func (t typeArgument) Describe() string {
+	return t.description
+}
+
+func isNil(actual interface{}) bool {
+	if actual == nil {
+		return true
+	}
+	value := reflect.ValueOf(actual)
+	return isNilSupported(value.Type()) && value.IsNil()
+}
@@ -0,0 +1,99 @@
+package double_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+
+	. "github.com/laurentdutheil/go-double/double"
+)
+
+func TestTypeMatcher(t *testing.T) {
+	var nilPointer *ExampleType
+	var nilSlice []int
+	var nilReader io.Reader
+
+	t.Run("IsNil", func(t *testing.T) {
+		t.Run("Match nil and nil values", func(t *testing.T) {
+			assert.True(t, IsNil().Matches(nil))
+			assert.True(t, IsNil().Matches(nilPointer))
+			assert.True(t, IsNil().Matches(nilSlice))
+			assert.True(t, IsNil().Matches(nilReader))
+			assert.False(t, IsNil().Matches(0))
+			assert.False(t, IsNil().Matches(&ExampleType{}))
+			assert.False(t, IsNil().Matches([]int{}))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "IsNil()", IsNil().Describe())
+		})
+	})
+
+	t.Run("NotNil", func(t *testing.T) {
+		t.Run("Match values that are not nil", func(t *testing.T) {
+			assert.True(t, NotNil().Matches(0))
+			assert.True(t, NotNil().Matches(&ExampleType{}))
+			assert.False(t, NotNil().Matches(nil))
+			assert.False(t, NotNil().Matches(nilPointer))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "NotNil()", NotNil().Describe())
+		})
+	})
+
+	t.Run("Implements", func(t *testing.T) {
+		t.Run("Match an argument implementing the interface", func(t *testing.T) {
+			assert.True(t, Implements[io.Reader]().Matches(&bytes.Buffer{}))
+			assert.False(t, Implements[io.Reader]().Matches(bytes.Buffer{}))
+			assert.False(t, Implements[io.Reader]().Matches(nil))
+		})
+
+		t.Run("Panic if the type is not an interface", func(t *testing.T) {
+			assert.PanicsWithValue(t, "assert: arguments: bytes.Buffer is not an interface", func() {
+				Implements[bytes.Buffer]()
+			})
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "Implements[io.Reader]()", Implements[io.Reader]().Describe())
+		})
+	})
+
+	t.Run("AssignableTo", func(t *testing.T) {
+		t.Run("Match an argument assignable to the type", func(t *testing.T) {
+			assert.True(t, AssignableTo[fmt.Stringer]().Matches(&bytes.Buffer{}))
+			assert.True(t, AssignableTo[int]().Matches(1))
+			assert.False(t, AssignableTo[int]().Matches(int64(1)))
+		})
+
+		t.Run("Match nil if the type is nillable", func(t *testing.T) {
+			assert.True(t, AssignableTo[*ExampleType]().Matches(nil))
+			assert.True(t, AssignableTo[io.Reader]().Matches(nil))
+			assert.False(t, AssignableTo[int]().Matches(nil))
+		})
+
+		t.Run("Describe", func(t *testing.T) {
+			assert.Equal(t, "AssignableTo[*double_test.ExampleType]()", AssignableTo[*ExampleType]().Describe())
+		})
+	})
+
+	t.Run("AnythingOfType", func(t *testing.T) {
+		t.Run("Match the short, package and package path names", func(t *testing.T) {
+			assert.True(t, AnythingOfType("ExampleType").Matches(ExampleType{}))
+			assert.True(t, AnythingOfType("double_test.ExampleType").Matches(ExampleType{}))
+			assert.True(t, AnythingOfType("*double_test.ExampleType").Matches(&ExampleType{}))
+			assert.True(t, AnythingOfType("github.com/laurentdutheil/go-double/double_test.ExampleType").Matches(ExampleType{}))
+			assert.True(t, AnythingOfType("*github.com/laurentdutheil/go-double/double_test.ExampleType").Matches(&ExampleType{}))
+			assert.True(t, AnythingOfType("[]int").Matches([]int{}))
+			assert.False(t, AnythingOfType("github.com/laurentdutheil/go-double/double_test.ExampleType").Matches(&ExampleType{}))
+		})
+
+		t.Run("Don't match nil", func(t *testing.T) {
+			assert.False(t, AnythingOfType("ExampleType").Matches(nil))
+			assert.True(t, AnythingOfType("*double_test.ExampleType").Matches(nilPointer))
+		})
+	})
+}
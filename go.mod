@@ -2,11 +2,13 @@ module github.com/laurentdutheil/go-double
 
 go 1.18
 
-require github.com/stretchr/testify v1.9.0
+require (
+	github.com/stretchr/objx v0.5.2
+	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/objx v0.5.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -53,8 +53,7 @@ func TestExample_Spy(t *testing.T) {
 	})
 
 	t.Run("as a spy of the real implementation", func(t *testing.T) {
-		spy := double.New[SpyRealDice](t)
-		spy.spied = SixDie{}
+		spy := double.Wrap[SpyAsStub](t, SixDie{})
 		game := Game{position: 12, dice: spy}
 
 		game.Play()
@@ -75,13 +74,3 @@ func (s *SpyAsStub) Roll() int {
 	arguments := s.Called()
 	return arguments.Int(0)
 }
-
-type SpyRealDice struct {
-	double.Spy
-	spied SixDie
-}
-
-func (s *SpyRealDice) Roll() int {
-	s.AddActualCall()
-	return s.spied.Roll()
-}